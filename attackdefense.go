@@ -0,0 +1,97 @@
+package elo
+
+import "math"
+
+// DefaultGoals is the average number of goals scored by each side in a match between evenly rated teams.
+const DefaultGoals float64 = 1.35
+
+// Strength is the attacking and defensive rating of a team.
+type Strength struct {
+	Attack  float64
+	Defense float64
+}
+
+// Scoreline is a predicted result, with the expected goals of each side and the most likely score.
+type Scoreline struct {
+	Goals    float64 // Goals is the expected number of goals of the subject team.
+	GoalsOpp float64 // GoalsOpp is the expected number of goals of the opposing team.
+	Score    int     // Score is the most likely number of goals of the subject team.
+	ScoreOpp int     // ScoreOpp is the most likely number of goals of the opposing team.
+}
+
+// AttackDefense rates teams in scoring sports with separate attacking and defensive ratings. The expected goals of
+// each side grow by a factor of ten for every c points its attack is above the opposing defence, starting from Goals
+// for evenly rated sides, with the home advantage added for the subject team. After each match both components are
+// updated through the configured update function, with the goals scored and conceded as the observed values.
+type AttackDefense struct {
+	Settings  Settings
+	Goals     float64 // Goals is the expected number of goals of each side when attack and defence are equal.
+	strengths map[string]Strength
+}
+
+// NewAttackDefense creates an attack and defence rater with the default average goals.
+func NewAttackDefense(s Settings) *AttackDefense {
+	return &AttackDefense{
+		Settings:  s,
+		Goals:     DefaultGoals,
+		strengths: map[string]Strength{},
+	}
+}
+
+// Strength returns the ratings of a team, which start at the seeded rating if they have not played.
+func (ad *AttackDefense) Strength(team string) Strength {
+	if st, ok := ad.strengths[team]; ok {
+		return st
+	}
+	return Strength{Attack: ad.Settings.SeedRating(team), Defense: ad.Settings.SeedRating(team)}
+}
+
+// expectedGoals returns the expected goals of an attack against a defence with the given home advantage.
+func (ad *AttackDefense) expectedGoals(attack float64, defense float64, homeAdvantage float64) float64 {
+	return ad.Goals * math.Pow(10, (attack+homeAdvantage-defense)/ad.Settings.c)
+}
+
+// Predict predicts the scoreline of a match, the subject team being at home.
+// It takes the following parameters:
+// - team (string): The subject team.
+// - teamOpp (string): The opposing team.
+// It returns the predicted scoreline, the most likely score being the mode of independent Poisson distributions.
+func (ad *AttackDefense) Predict(team string, teamOpp string) Scoreline {
+	st, stOpp := ad.Strength(team), ad.Strength(teamOpp)
+	goals := ad.expectedGoals(st.Attack, stOpp.Defense, ad.Settings.homeAdvantage)
+	goalsOpp := ad.expectedGoals(stOpp.Attack, st.Defense, 0)
+	return Scoreline{
+		Goals:    goals,
+		GoalsOpp: goalsOpp,
+		Score:    int(math.Floor(goals)),
+		ScoreOpp: int(math.Floor(goalsOpp)),
+	}
+}
+
+// Update applies the score of a record, the subject team being at home. Each attack moves by the goals scored against
+// the goals expected, and each defence by the goals expected against the goals conceded.
+// It takes the following parameters:
+// - rec (Record): The result to apply.
+// It returns the scoreline that was predicted before the update.
+func (ad *AttackDefense) Update(rec Record) Scoreline {
+	if ad.strengths == nil {
+		ad.strengths = map[string]Strength{}
+	}
+	predicted := ad.Predict(rec.Player, rec.PlayerOpp)
+	st, stOpp := ad.Strength(rec.Player), ad.Strength(rec.PlayerOpp)
+	ad.strengths[rec.Player] = Strength{
+		Attack:  ad.move(st.Attack, rec.Score, predicted.Goals),
+		Defense: ad.move(st.Defense, predicted.GoalsOpp, rec.ScoreOpp),
+	}
+	ad.strengths[rec.PlayerOpp] = Strength{
+		Attack:  ad.move(stOpp.Attack, rec.ScoreOpp, predicted.GoalsOpp),
+		Defense: ad.move(stOpp.Defense, predicted.Goals, rec.Score),
+	}
+	return predicted
+}
+
+// move updates a single attacking or defensive rating through the configured update function and policies.
+func (ad *AttackDefense) move(rating float64, observed float64, expected float64) float64 {
+	newRating, _ := ad.Settings.update(PlayerTeam{RatingRaw: rating, Rating: rating}, observed, expected, ad.Settings.kFactor, 0)
+	return newRating
+}
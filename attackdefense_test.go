@@ -0,0 +1,54 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestAttackDefenseUpdate(t *testing.T) {
+	ad := elo.NewAttackDefense(elo.New(elo.WithHomeAdvantage(0)))
+
+	// Test case 1: Evenly rated teams are expected to score the average
+	result := ad.Update(elo.Record{Player: "a", PlayerOpp: "b", Score: 3, ScoreOpp: 0})
+	expectedResult := elo.DefaultGoals
+	if result.Goals != expectedResult || result.GoalsOpp != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.Goals)
+	}
+
+	// Test case 2: Both components move from goals scored and conceded
+	st := ad.Strength("a")
+	expectedResult = 2600 + 32*(3-elo.DefaultGoals)
+	if math.Abs(st.Attack-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, st.Attack)
+	}
+	expectedResult = 2600 + 32*elo.DefaultGoals
+	if math.Abs(st.Defense-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, st.Defense)
+	}
+	stOpp := ad.Strength("b")
+	expectedResult = 2600 + 32*(elo.DefaultGoals-3)
+	if math.Abs(stOpp.Defense-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, stOpp.Defense)
+	}
+
+	// Test case 3: Predictions come from attack against the opposing defence
+	prediction := ad.Predict("a", "b")
+	expectedResult = elo.DefaultGoals * math.Pow(10, (st.Attack-stOpp.Defense)/400)
+	if math.Abs(prediction.Goals-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, prediction.Goals)
+	}
+	if prediction.Score != int(math.Floor(expectedResult)) {
+		t.Errorf("Expected %d, but got %d", int(math.Floor(expectedResult)), prediction.Score)
+	}
+
+	// Test case 4: A high scoring team with a leaky defence is told apart
+	for i := 0; i < 5; i++ {
+		ad.Update(elo.Record{Player: "c", PlayerOpp: "d", Score: 3, ScoreOpp: 3})
+	}
+	st = ad.Strength("c")
+	if st.Attack <= 2600 || st.Defense >= 2600 {
+		t.Errorf("Expected a strong attack and weak defence, but got %v", st)
+	}
+}
@@ -0,0 +1,173 @@
+package elo
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	DefaultSamples    int     = 1000
+	DefaultConfidence float64 = 0.95
+)
+
+// Rater is a function type that rates a batch of records, returning the rating of each player.
+type Rater func(records []Record) map[string]float64
+
+// Replay returns a rater that applies the records in time order through Match, as a Ledger does.
+func (s Settings) Replay() Rater {
+	return func(records []Record) map[string]float64 {
+		l := NewLedger(s)
+		for _, rec := range records {
+			// invalid records are left out of the replay
+			_, _ = l.Insert(rec)
+		}
+		return l.Ratings()
+	}
+}
+
+// Interval summarises the spread of a player's rating over the bootstrap samples they appeared in.
+type Interval struct {
+	Mean   float64
+	Lower  float64 // Lower is the lower bound of the confidence interval.
+	Median float64
+	Upper  float64 // Upper is the upper bound of the confidence interval.
+}
+
+// BootstrapResult holds the confidence interval of each player's rating and the probability of each rank position.
+type BootstrapResult struct {
+	Intervals map[string]Interval
+	Ranks     Positions
+}
+
+// Bootstrap resamples a match history with replacement and re-rates every sample to estimate how precise the ratings are.
+type Bootstrap struct {
+	Rater      Rater
+	Samples    int     // Samples is the number of resampled histories to rate.
+	Seed       int64   // Seed is the seed of the first sample, each sample uses its own seed so results do not depend on Workers.
+	Workers    int     // Workers is the number of samples rated in parallel.
+	Confidence float64 // Confidence is the share of samples inside each interval.
+}
+
+// NewBootstrap creates a bootstrap of the given rater with default samples, workers and confidence.
+func NewBootstrap(rater Rater) Bootstrap {
+	return Bootstrap{
+		Rater:      rater,
+		Samples:    DefaultSamples,
+		Workers:    runtime.NumCPU(),
+		Confidence: DefaultConfidence,
+	}
+}
+
+// quantile returns the q quantile of sorted values by linear interpolation.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// Run rates the resampled histories in parallel. Each sample keeps the records in their original order so that an
+// incremental rater sees them in sequence.
+// It takes the following parameters:
+// - records ([]Record): The match history to resample.
+// It returns the interval of each player and the probability of each player finishing in each rank, rank 0 being the
+// highest rating, both over the samples the player appears in.
+func (b Bootstrap) Run(records []Record) BootstrapResult {
+	if len(records) == 0 {
+		return BootstrapResult{Intervals: map[string]Interval{}, Ranks: Positions{}}
+	}
+	samples := b.Samples
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	workers := b.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	ratings := make([]map[string]float64, samples)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sample := range jobs {
+				rng := rand.New(rand.NewSource(b.Seed + int64(sample)))
+				idx := make([]int, len(records))
+				for i := range idx {
+					idx[i] = rng.Intn(len(records))
+				}
+				sort.Ints(idx)
+				resampled := make([]Record, len(idx))
+				for i, j := range idx {
+					resampled[i] = records[j]
+				}
+				ratings[sample] = b.Rater(resampled)
+			}
+		}()
+	}
+	for sample := 0; sample < samples; sample++ {
+		jobs <- sample
+	}
+	close(jobs)
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, rec := range records {
+		seen[rec.Player], seen[rec.PlayerOpp] = true, true
+	}
+	values := make(map[string][]float64, len(seen))
+	counts := make(map[string][]int, len(seen))
+	for player := range seen {
+		counts[player] = make([]int, len(seen))
+	}
+	for _, sample := range ratings {
+		order := make([]string, 0, len(sample))
+		for player, rating := range sample {
+			values[player] = append(values[player], rating)
+			order = append(order, player)
+		}
+		sort.Slice(order, func(i, j int) bool {
+			if sample[order[i]] != sample[order[j]] {
+				return sample[order[i]] > sample[order[j]]
+			}
+			return order[i] < order[j]
+		})
+		for rank, player := range order {
+			if c, ok := counts[player]; ok && rank < len(c) {
+				c[rank]++
+			}
+		}
+	}
+
+	alpha := (1 - b.Confidence) / 2
+	result := BootstrapResult{Intervals: map[string]Interval{}, Ranks: Positions{}}
+	for player, vals := range values {
+		sort.Float64s(vals)
+		mean := 0.0
+		for _, v := range vals {
+			mean += v
+		}
+		result.Intervals[player] = Interval{
+			Mean:   mean / float64(len(vals)),
+			Lower:  quantile(vals, alpha),
+			Median: quantile(vals, 0.5),
+			Upper:  quantile(vals, 1-alpha),
+		}
+	}
+	for player, c := range counts {
+		result.Ranks[player] = make([]float64, len(c))
+		if n := len(values[player]); n > 0 {
+			for rank, count := range c {
+				result.Ranks[player][rank] = float64(count) / float64(n)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package elo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestBootstrapRun(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	records := []elo.Record{}
+	for d := 1; d <= 10; d++ {
+		records = append(records,
+			elo.Record{Time: day(d), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+			elo.Record{Time: day(d), Player: "b", PlayerOpp: "c", Score: 1, ScoreOpp: 0},
+			elo.Record{Time: day(d), Player: "c", PlayerOpp: "a", Score: float64(d % 3 / 2), ScoreOpp: 1},
+		)
+	}
+
+	for _, rater := range []elo.Rater{settings.Replay(), elo.NewBradleyTerry(settings).Rater()} {
+		b := elo.NewBootstrap(rater)
+		b.Samples = 200
+		b.Seed = 3
+		b.Workers = 1
+		result := b.Run(records)
+
+		// Test case 1: Intervals are ordered and contain the median
+		for player, interval := range result.Intervals {
+			if !(interval.Lower <= interval.Median && interval.Median <= interval.Upper) {
+				t.Errorf("Expected an ordered interval for %s, but got %v", player, interval)
+			}
+		}
+
+		// Test case 2: The dominant player is most likely ranked first
+		if result.Ranks.Top("a", 1) <= result.Ranks.Top("b", 1) {
+			t.Errorf("Expected a to rank first most often, but got %f against %f", result.Ranks.Top("a", 1), result.Ranks.Top("b", 1))
+		}
+		if result.Ranks.Bottom("c", 1) < 0.5 {
+			t.Errorf("Expected c to rank last most often, but got %f", result.Ranks.Bottom("c", 1))
+		}
+
+		// Test case 3: Seeded results do not depend on the number of workers
+		b.Workers = 4
+		if parallel := b.Run(records); !reflect.DeepEqual(result, parallel) {
+			t.Errorf("Expected %v, but got %v", result, parallel)
+		}
+	}
+}
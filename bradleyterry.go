@@ -0,0 +1,185 @@
+package elo
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+const (
+	DefaultMaxIter        int     = 1000
+	DefaultTolerance      float64 = 1e-9
+	DefaultRegularization float64 = 0.01
+)
+
+var (
+	ErrSeparated    = errors.New("elo: some players have no finite rating without regularization, as they beat or lost to everyone on one side of a split")
+	ErrNotConverged = errors.New("elo: fit did not converge within MaxIter")
+)
+
+// BradleyTerry fits ratings to a whole batch of records by maximum likelihood, so that unlike incremental updates the
+// result does not depend on the order the matches were played in. Strengths are fitted by cyclic Newton steps and
+// returned on the scale of ExpProbability with the configured c, centred on the initial rating.
+type BradleyTerry struct {
+	Settings       Settings
+	Ties           bool    // Ties counts a draw as half a win for each side, otherwise draws are left out.
+	Regularization float64 // Regularization is the L2 penalty pulling strengths towards the initial rating.
+	MaxIter        int     // MaxIter is the maximum number of passes over the players.
+	Tolerance      float64 // Tolerance is the largest change in strength at which the fit is considered converged.
+}
+
+// NewBradleyTerry creates a Bradley-Terry fit that counts ties, with a small default regularization so that unbeaten
+// and winless players still have a finite rating.
+func NewBradleyTerry(s Settings) BradleyTerry {
+	return BradleyTerry{
+		Settings:       s,
+		Ties:           true,
+		Regularization: DefaultRegularization,
+		MaxIter:        DefaultMaxIter,
+		Tolerance:      DefaultTolerance,
+	}
+}
+
+// pairwise holds the games and wins of a player against each opponent.
+type pairwise struct {
+	opponents []string // opponents holds the sorted names of the opponents, so sums are taken in a fixed order.
+	games     map[string]float64
+	wins      map[string]float64
+}
+
+// tally aggregates records into games and wins between each pair of players, using the observed function of the
+// settings clamped between 0 and 1 as the share of a win.
+// It takes the following parameters:
+// - records ([]Record): The results to aggregate.
+// - ties (bool): Whether results that are neither a win nor a loss are kept.
+// It returns the aggregate for each player and the sorted names of the players.
+func (s *Settings) tally(records []Record, ties bool) (map[string]*pairwise, []string) {
+	stats := map[string]*pairwise{}
+	get := func(player string) *pairwise {
+		if stats[player] == nil {
+			stats[player] = &pairwise{games: map[string]float64{}, wins: map[string]float64{}}
+		}
+		return stats[player]
+	}
+	for _, rec := range records {
+		observed := math.Max(0, math.Min(1, s.observed(rec.Score, rec.ScoreOpp)))
+		if !ties && observed != 0 && observed != 1 {
+			continue
+		}
+		p, pOpp := get(rec.Player), get(rec.PlayerOpp)
+		p.games[rec.PlayerOpp]++
+		pOpp.games[rec.Player]++
+		p.wins[rec.PlayerOpp] += observed
+		pOpp.wins[rec.Player] += 1 - observed
+	}
+	players := make([]string, 0, len(stats))
+	for player, p := range stats {
+		players = append(players, player)
+		for opp := range p.games {
+			p.opponents = append(p.opponents, opp)
+		}
+		sort.Strings(p.opponents)
+	}
+	sort.Strings(players)
+	return stats, players
+}
+
+// sigmoid returns the logistic function of x.
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// separated reports whether the players can be split into two groups with one group winning every game between them,
+// in which case the likelihood alone has no maximum. Draws count as a partial win for both sides, so the players are
+// not separated when every player can reach every other through a chain of opponents they took points from.
+func separated(stats map[string]*pairwise, players []string) bool {
+	if len(players) == 0 {
+		return false
+	}
+	reach := func(beaten func(player string, opp string) bool) int {
+		seen := map[string]bool{players[0]: true}
+		queue := []string{players[0]}
+		for len(queue) > 0 {
+			player := queue[0]
+			queue = queue[1:]
+			for _, opp := range stats[player].opponents {
+				if !seen[opp] && beaten(player, opp) {
+					seen[opp] = true
+					queue = append(queue, opp)
+				}
+			}
+		}
+		return len(seen)
+	}
+	forward := reach(func(player string, opp string) bool { return stats[player].wins[opp] > 0 })
+	backward := reach(func(player string, opp string) bool { return stats[opp].wins[player] > 0 })
+	return forward < len(players) || backward < len(players)
+}
+
+// Fit fits the ratings of every player appearing in the records.
+// It takes the following parameters:
+// - records ([]Record): The results to fit.
+// It returns the fitted rating of each player, or an error if the ratings are not finite without regularization or
+// the fit does not converge.
+func (bt BradleyTerry) Fit(records []Record) (map[string]float64, error) {
+	stats, players := bt.Settings.tally(records, bt.Ties)
+	if bt.Regularization == 0 && separated(stats, players) {
+		return nil, ErrSeparated
+	}
+	strength := make(map[string]float64, len(players))
+	converged := len(players) == 0
+	for i := 0; i < bt.MaxIter; i++ {
+		largest := 0.0
+		for _, player := range players {
+			p := stats[player]
+			gradient := -bt.Regularization * strength[player]
+			hessian := bt.Regularization
+			for _, opp := range p.opponents {
+				games := p.games[opp]
+				prob := sigmoid(strength[player] - strength[opp])
+				gradient += p.wins[opp] - games*prob
+				hessian += games * prob * (1 - prob)
+			}
+			if hessian == 0 {
+				continue
+			}
+			// limit each step so that unbeaten players move steadily rather than diverging in one pass
+			step := math.Max(-1, math.Min(1, gradient/hessian))
+			strength[player] += step
+			largest = math.Max(largest, math.Abs(step))
+		}
+		if largest < bt.Tolerance {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return nil, ErrNotConverged
+	}
+
+	// the likelihood alone only fixes differences in strength, so centre the unregularized fit
+	if bt.Regularization == 0 && len(players) > 0 {
+		mean := 0.0
+		for _, player := range players {
+			mean += strength[player]
+		}
+		mean /= float64(len(players))
+		for _, player := range players {
+			strength[player] -= mean
+		}
+	}
+
+	ratings := make(map[string]float64, len(players))
+	for _, player := range players {
+		ratings[player] = bt.Settings.InitRating + strength[player]*bt.Settings.c/math.Ln10
+	}
+	return ratings, nil
+}
+
+// Rater adapts the fit for use in a Bootstrap, a sample that cannot be fitted giving no ratings.
+func (bt BradleyTerry) Rater() Rater {
+	return func(records []Record) map[string]float64 {
+		ratings, _ := bt.Fit(records)
+		return ratings
+	}
+}
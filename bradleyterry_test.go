@@ -0,0 +1,96 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestBradleyTerryFit(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500))
+	records := []elo.Record{
+		{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Player: "b", PlayerOpp: "a", Score: 0, ScoreOpp: 1},
+		{Player: "a", PlayerOpp: "b", Score: 2, ScoreOpp: 1},
+		{Player: "a", PlayerOpp: "b", Score: 0, ScoreOpp: 1},
+		{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1},
+	}
+
+	// Test case 1: Three wins from four games gives a 75% expected score on the Elo scale
+	bt := elo.NewBradleyTerry(settings)
+	bt.Ties = false
+	bt.Regularization = 0
+	result, err := bt.Fit(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedResult := 0.75
+	prob := elo.ExpProbability(result["a"], result["b"], 0, elo.DefaultC)
+	if math.Abs(prob-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, prob)
+	}
+	expectedResult = 1500.0
+	mean := (result["a"] + result["b"]) / 2
+	if math.Abs(mean-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, mean)
+	}
+
+	// Test case 2: Counting the draw as half a win
+	bt.Ties = true
+	if result, err = bt.Fit(records); err != nil {
+		t.Fatal(err)
+	}
+	expectedResult = 0.7
+	prob = elo.ExpProbability(result["a"], result["b"], 0, elo.DefaultC)
+	if math.Abs(prob-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, prob)
+	}
+
+	// Test case 3: Regularization shrinks ratings towards the initial rating
+	bt.Regularization = 1
+	shrunk, err := bt.Fit(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shrunk["a"]-shrunk["b"] >= result["a"]-result["b"] {
+		t.Errorf("Expected a smaller gap than %f, but got %f", result["a"]-result["b"], shrunk["a"]-shrunk["b"])
+	}
+}
+
+func TestBradleyTerrySeparated(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500))
+	records := []elo.Record{
+		{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Player: "b", PlayerOpp: "c", Score: 1, ScoreOpp: 0},
+		{Player: "c", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+	}
+
+	// Test case 1: An unbeaten player has no finite rating without regularization
+	bt := elo.NewBradleyTerry(settings)
+	bt.Regularization = 0
+	if _, err := bt.Fit(records); err != elo.ErrSeparated {
+		t.Errorf("Expected %v, but got %v", elo.ErrSeparated, err)
+	}
+
+	// Test case 2: A draw against the unbeaten player joins the groups up
+	withDraw := append(records, elo.Record{Player: "c", PlayerOpp: "a", Score: 1, ScoreOpp: 1})
+	if _, err := bt.Fit(withDraw); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+
+	// Test case 3: The default regularization gives a finite rating
+	result, err := elo.NewBradleyTerry(settings).Fit(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.IsInf(result["a"], 0) || result["a"] <= result["b"] {
+		t.Errorf("Expected a finite rating above b, but got %f", result["a"])
+	}
+
+	// Test case 4: Running out of iterations is reported
+	bt.MaxIter = 1
+	if _, err := bt.Fit(withDraw); err != elo.ErrNotConverged {
+		t.Errorf("Expected %v, but got %v", elo.ErrNotConverged, err)
+	}
+}
@@ -0,0 +1,128 @@
+package elo
+
+import "math"
+
+// Contextual keeps a rating for each context a player has played in, such as a surface, map or time control, alongside
+// a shared overall rating whose peak and games feed the policies and the provisional period. Predictions blend the
+// context rating with the overall rating before they are passed to the expected function, and each result moves both
+// ratings by the change of the blended rating.
+type Contextual struct {
+	Settings Settings
+	Weight   float64               // Weight is the share of the context rating in the blended rating, the rest coming from the overall rating.
+	Weights  map[string]float64    // Weights overrides Weight for individual contexts.
+	players  map[string]PlayerTeam // players holds the overall rating, peak and games of each player.
+	contexts map[string]map[string]float64
+}
+
+// NewContextual creates a context rater giving the context rating the given share of the blended rating.
+func NewContextual(s Settings, weight float64) *Contextual {
+	return &Contextual{
+		Settings: s,
+		Weight:   weight,
+		Weights:  map[string]float64{},
+		players:  map[string]PlayerTeam{},
+		contexts: map[string]map[string]float64{},
+	}
+}
+
+// Overall returns the overall rating of a player, or a seeded rating if they have not played.
+func (c *Contextual) Overall(player string) float64 {
+	return c.player(player).RatingRaw
+}
+
+// player returns the overall state of a player, seeding players the rater has not seen.
+func (c *Contextual) player(player string) PlayerTeam {
+	if pt, ok := c.players[player]; ok {
+		return pt
+	}
+	return c.Settings.newPlayer(player)
+}
+
+// Context returns the rating of a player in a context, which starts from their overall rating if they have not played
+// in it. Records without a context only have the overall rating.
+func (c *Contextual) Context(player string, context string) float64 {
+	if context != "" {
+		if rating, ok := c.contexts[context][player]; ok {
+			return rating
+		}
+	}
+	return c.Overall(player)
+}
+
+// weight returns the share of the context rating for a context.
+func (c *Contextual) weight(context string) float64 {
+	if w, ok := c.Weights[context]; ok {
+		return w
+	}
+	return c.Weight
+}
+
+// Rating returns the blended rating of a player in a context.
+func (c *Contextual) Rating(player string, context string) float64 {
+	w := c.weight(context)
+	return w*c.Context(player, context) + (1-w)*c.Overall(player)
+}
+
+// Expected calculates the expected value of a match in a context from the blended ratings of both players.
+func (c *Contextual) Expected(player string, playerOpp string, context string) float64 {
+	return c.Settings.Expected(c.Rating(player, context), c.Rating(playerOpp, context))
+}
+
+// Update applies a record to both players through Match using their blended ratings in the record's context, and moves
+// both the context and the overall rating of each player by the resulting change.
+// It takes the following parameters:
+// - rec (Record): The result to apply.
+// It returns the change of the blended rating of the subject and of the opposition.
+func (c *Contextual) Update(rec Record) (float64, float64) {
+	if c.players == nil {
+		c.players = map[string]PlayerTeam{}
+		c.contexts = map[string]map[string]float64{}
+	}
+	rating, ratingOpp := c.Rating(rec.Player, rec.Context), c.Rating(rec.PlayerOpp, rec.Context)
+	overall, overallOpp := c.player(rec.Player), c.player(rec.PlayerOpp)
+	pt, ptOpp := overall, overallOpp
+	pt.RatingRaw, ptOpp.RatingRaw = rating, ratingOpp
+	newRating, newRatingOpp := play(c.Settings, rec, pt, ptOpp)
+	change, changeOpp := newRating-rating, newRatingOpp-ratingOpp
+
+	if rec.Context != "" {
+		if c.contexts[rec.Context] == nil {
+			c.contexts[rec.Context] = map[string]float64{}
+		}
+		c.contexts[rec.Context][rec.Player] = c.Context(rec.Player, rec.Context) + change
+		c.contexts[rec.Context][rec.PlayerOpp] = c.Context(rec.PlayerOpp, rec.Context) + changeOpp
+	}
+	c.players[rec.Player] = overall.after(overall.RatingRaw + change)
+	c.players[rec.PlayerOpp] = overallOpp.after(overallOpp.RatingRaw + changeOpp)
+	return change, changeOpp
+}
+
+// Learn chooses the weight that best predicts a history, replaying it from new ratings for each weight on an evenly
+// spaced grid and scoring the squared error of the expected value before each update. Contexts with their own entry in
+// Weights keep it throughout. The weight is then set on the rater, whose ratings are left unchanged.
+// It takes the following parameters:
+// - records ([]Record): The history to learn from, in time order.
+// - steps (int): The number of intervals the range from 0 to 1 is divided into.
+// It returns the chosen weight.
+func (c *Contextual) Learn(records []Record, steps int) float64 {
+	if steps < 1 {
+		steps = 1
+	}
+	best, bestError := c.Weight, math.Inf(1)
+	for i := 0; i <= steps; i++ {
+		w := float64(i) / float64(steps)
+		trial := NewContextual(c.Settings, w)
+		trial.Weights = c.Weights
+		total := 0.0
+		for _, rec := range records {
+			miss := trial.Settings.observed(rec.Score, rec.ScoreOpp) - trial.Expected(rec.Player, rec.PlayerOpp, rec.Context)
+			total += miss * miss
+			trial.Update(rec)
+		}
+		if total < bestError {
+			best, bestError = w, total
+		}
+	}
+	c.Weight = best
+	return best
+}
@@ -0,0 +1,74 @@
+package elo_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestContextualUpdate(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500), elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	c := elo.NewContextual(settings, 0.75)
+	c.Update(elo.Record{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0, Context: "clay"})
+
+	// Test case 1: Both the context and the overall rating move by the same change
+	expectedResult := 1516.0
+	if result := c.Overall("a"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	if result := c.Context("a", "clay"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: A new context starts from the overall rating
+	if result := c.Context("a", "grass"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Predictions blend the context and overall ratings
+	c.Update(elo.Record{Player: "a", PlayerOpp: "b", Score: 0, ScoreOpp: 1, Context: "grass"})
+	blended := 0.75*c.Context("a", "clay") + 0.25*c.Overall("a")
+	blendedOpp := 0.75*c.Context("b", "clay") + 0.25*c.Overall("b")
+	expectedResult = settings.Expected(blended, blendedOpp)
+	if result := c.Expected("a", "b", "clay"); math.Abs(result-expectedResult) > 1e-12 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 4: Peaks are tracked so repeated losses stop at the floor
+	settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithInitRating(1990), elo.WithFloorFunc(elo.FloorFromPeak))
+	c = elo.NewContextual(settings, 0.5)
+	c.Update(elo.Record{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	for i := 0; i < 30; i++ {
+		c.Update(elo.Record{Player: "a", PlayerOpp: fmt.Sprint(i), Score: 0, ScoreOpp: 1})
+	}
+	expectedResult = 1800.0
+	if result := c.Overall("a"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestContextualLearn(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500), elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	records := []elo.Record{}
+	for i := 0; i < 20; i++ {
+		records = append(records,
+			elo.Record{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0, Context: "clay"},
+			elo.Record{Player: "a", PlayerOpp: "b", Score: 0, ScoreOpp: 1, Context: "grass"},
+		)
+	}
+
+	// Test case 1: Results that depend on the surface favour the context rating
+	c := elo.NewContextual(settings, 0)
+	result := c.Learn(records, 10)
+	if result <= 0.5 || c.Weight != result {
+		t.Errorf("Expected a weight above 0.5, but got %f", result)
+	}
+
+	// Test case 2: Learning leaves the ratings unchanged
+	expectedResult := 1500.0
+	if rating := c.Overall("a"); rating != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, rating)
+	}
+}
@@ -0,0 +1,195 @@
+package elo
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+const (
+	CurveNameLogistic10 = "logistic10"
+	CurveNameLogistic   = "logistic"
+	CurveNameGaussian   = "gaussian"
+)
+
+// ProbabilityLimit is how close to 0 or 1 a probability is taken to be when it is inverted, so that certain results
+// give a large but finite rating difference.
+const ProbabilityLimit float64 = 1e-9
+
+// Inverse is a function type that defines the signature of an inverse expected function, giving the rating difference
+// (rating + homeAdvantage - ratingOpp) that produces an expected value.
+type Inverse func(expected float64, c float64) float64
+
+// Curve is a family of expected score curves along with its inverse. Slope is the gradient of the curve at an even
+// match when c is 1, which is what scale conversion between curves is based on.
+type Curve struct {
+	Name     string
+	Expected Expected
+	Inverse  Inverse
+	Slope    float64
+}
+
+var (
+	CurveLogistic10 = Curve{Name: CurveNameLogistic10, Expected: ExpProbability, Inverse: InvLogistic10, Slope: math.Ln10 / 4}
+	CurveLogistic   = Curve{Name: CurveNameLogistic, Expected: ExpLogistic, Inverse: InvLogistic, Slope: 0.25}
+	CurveGaussian   = Curve{Name: CurveNameGaussian, Expected: ExpGaussian, Inverse: InvGaussian, Slope: 1 / math.Sqrt(2*math.Pi)}
+
+	curvesMu sync.RWMutex
+	curves   = map[string]Curve{
+		CurveNameLogistic10: CurveLogistic10,
+		CurveNameLogistic:   CurveLogistic,
+		CurveNameGaussian:   CurveGaussian,
+	}
+)
+
+// ExpLogistic is an expected function that calculates the probability of a subject team winning with a natural
+// logistic curve, the odds growing by a factor of e for every c points of rating difference.
+// It takes the following parameters:
+// - rating (float64): The rating of the subject team.
+// - ratingOpp (float64): The rating of the opposing team.
+// - homeAdvantage (float64): The home advantage factor (if any).
+// - c (float64): A scaling factor that affects the steepness of the probability curve.
+// It returns a float64 value representing the probability of the subject team winning the match.
+func ExpLogistic(rating float64, ratingOpp float64, homeAdvantage float64, c float64) float64 {
+	difference := (rating + homeAdvantage) - ratingOpp
+	return 1 / (1 + math.Exp(-difference/c))
+}
+
+// ExpGaussian is an expected function that calculates the probability of a subject team winning with the normal
+// curve of the Thurstone-Mosteller model, c being the standard deviation of the difference in performances.
+// It takes the following parameters:
+// - rating (float64): The rating of the subject team.
+// - ratingOpp (float64): The rating of the opposing team.
+// - homeAdvantage (float64): The home advantage factor (if any).
+// - c (float64): The standard deviation of the difference in performances.
+// It returns a float64 value representing the probability of the subject team winning the match.
+func ExpGaussian(rating float64, ratingOpp float64, homeAdvantage float64, c float64) float64 {
+	difference := (rating + homeAdvantage) - ratingOpp
+	return 0.5 * math.Erfc(-difference/(c*math.Sqrt2))
+}
+
+// InvLogistic10 is the inverse of ExpProbability.
+// It takes the following parameters:
+// - expected (float64): The probability of the subject team winning.
+// - c (float64): The scaling factor of the curve.
+// It returns the rating difference implied by the probability, 0 and 1 being taken as ProbabilityLimit away.
+func InvLogistic10(expected float64, c float64) float64 {
+	expected = limitProbability(expected)
+	return c * math.Log10(expected/(1-expected))
+}
+
+// InvLogistic is the inverse of ExpLogistic.
+// It takes the following parameters:
+// - expected (float64): The probability of the subject team winning.
+// - c (float64): The scaling factor of the curve.
+// It returns the rating difference implied by the probability, 0 and 1 being taken as ProbabilityLimit away.
+func InvLogistic(expected float64, c float64) float64 {
+	expected = limitProbability(expected)
+	return c * math.Log(expected/(1-expected))
+}
+
+// InvGaussian is the inverse of ExpGaussian.
+// It takes the following parameters:
+// - expected (float64): The probability of the subject team winning.
+// - c (float64): The standard deviation of the difference in performances.
+// It returns the rating difference implied by the probability, 0 and 1 being taken as ProbabilityLimit away.
+func InvGaussian(expected float64, c float64) float64 {
+	expected = limitProbability(expected)
+	return c * math.Sqrt2 * math.Erfinv(2*expected-1)
+}
+
+// InvDifference is the inverse of ExpDifference.
+// It takes the following parameters:
+// - expected (float64): The expected score difference.
+// - c (float64): A scaling factor (not used in this function).
+// It returns the rating difference implied by the expected score difference.
+func InvDifference(expected float64, c float64) float64 {
+	return expected
+}
+
+// limitProbability keeps a probability at least ProbabilityLimit away from 0 and 1.
+func limitProbability(p float64) float64 {
+	return math.Max(ProbabilityLimit, math.Min(1-ProbabilityLimit, p))
+}
+
+// builtinInverses pairs each named built-in expected function with its inverse.
+var builtinInverses = []struct {
+	expected Expected
+	inverse  Inverse
+}{
+	{ExpProbability, InvLogistic10},
+	{ExpLogistic, InvLogistic},
+	{ExpGaussian, InvGaussian},
+	{ExpDifference, InvDifference},
+}
+
+// inverseOf finds the inverse of a named built-in expected function by comparing function pointers. Closures share the
+// code pointer of the literal they come from, so other expected functions, including registered curves, are not
+// matched and their inverse has to be given with WithCurve or WithInverseFunc.
+// It returns the inverse, or nil if the expected function is not a named built-in.
+func inverseOf(expected Expected) *Inverse {
+	ptr := reflect.ValueOf(expected).Pointer()
+	for _, builtin := range builtinInverses {
+		if reflect.ValueOf(builtin.expected).Pointer() == ptr {
+			inverse := builtin.inverse
+			return &inverse
+		}
+	}
+	return nil
+}
+
+// RegisterCurve adds a curve to the registry so it can be found by name, replacing any curve with the same name.
+func RegisterCurve(curve Curve) {
+	curvesMu.Lock()
+	defer curvesMu.Unlock()
+	curves[curve.Name] = curve
+}
+
+// CurveByName finds a registered curve, returning false if there is no curve with the name.
+func CurveByName(name string) (Curve, bool) {
+	curvesMu.RLock()
+	defer curvesMu.RUnlock()
+	curve, ok := curves[name]
+	return curve, ok
+}
+
+// Curves returns the sorted names of the registered curves.
+func Curves() []string {
+	curvesMu.RLock()
+	defer curvesMu.RUnlock()
+	return sortedCurves()
+}
+
+// sortedCurves returns the sorted names of the registered curves, the caller holding the lock.
+func sortedCurves() []string {
+	names := make([]string, 0, len(curves))
+	for name := range curves {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConvertScale converts a scaling factor between curves so that both give the same gradient at an even match, for
+// example 400 on the base ten logistic curve is about 173.7 on the natural logistic curve and 277.2 on the normal curve.
+// It takes the following parameters:
+// - c (float64): The scaling factor on the original curve.
+// - from (Curve): The original curve.
+// - to (Curve): The target curve.
+// It returns the equivalent scaling factor on the target curve.
+func ConvertScale(c float64, from Curve, to Curve) float64 {
+	return c * to.Slope / from.Slope
+}
+
+// ConvertRating converts a rating between curves using the same scaling factor, stretching its distance from a
+// reference rating so that predictions near an even match are unchanged.
+// It takes the following parameters:
+// - rating (float64): The rating on the original curve.
+// - reference (float64): The rating that is the same on both curves, such as the initial rating.
+// - from (Curve): The original curve.
+// - to (Curve): The target curve.
+// It returns the equivalent rating on the target curve.
+func ConvertRating(rating float64, reference float64, from Curve, to Curve) float64 {
+	return reference + (rating-reference)*from.Slope/to.Slope
+}
@@ -0,0 +1,142 @@
+package elo_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestExpCurves(t *testing.T) {
+	// Test case 1: Natural logistic curve
+	result := elo.ExpLogistic(1500, 1400, 0, 100)
+	expectedResult := 1 / (1 + math.Exp(-1))
+	if math.Abs(result-expectedResult) > 1e-12 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: Normal curve one standard deviation apart, with home advantage
+	result = elo.ExpGaussian(1400, 1500, 500, 400)
+	expectedResult = 0.841345
+	if math.Abs(result-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Every registered curve is inverted by its inverse
+	for _, name := range elo.Curves() {
+		curve, _ := elo.CurveByName(name)
+		for _, difference := range []float64{-350, 0, 120} {
+			result := curve.Inverse(curve.Expected(difference, 0, 0, 400), 400)
+			if math.Abs(result-difference) > 1e-6 {
+				t.Errorf(ERROR_MESSAGE, difference, result)
+			}
+		}
+	}
+}
+
+func TestCurveRegistry(t *testing.T) {
+	// Test case 1: Built-in curves are registered
+	for _, name := range []string{elo.CurveNameGaussian, elo.CurveNameLogistic, elo.CurveNameLogistic10} {
+		if _, ok := elo.CurveByName(name); !ok {
+			t.Errorf("Expected %v to be registered", name)
+		}
+	}
+
+	// Test case 2: A custom curve can be registered and used through the settings
+	linear := elo.Curve{
+		Name: "linear",
+		Expected: func(rating float64, ratingOpp float64, homeAdvantage float64, c float64) float64 {
+			return math.Max(0, math.Min(1, 0.5+(rating+homeAdvantage-ratingOpp)/(2*c)))
+		},
+		Inverse: func(expected float64, c float64) float64 {
+			return (expected - 0.5) * 2 * c
+		},
+		Slope: 0.5,
+	}
+	elo.RegisterCurve(linear)
+	curve, ok := elo.CurveByName("linear")
+	if !ok {
+		t.Fatalf("Expected the linear curve to be registered")
+	}
+	settings := elo.New(elo.WithCurve(curve), elo.WithHomeAdvantage(0))
+	result := settings.Expected(1600, 1400)
+	expectedResult := 0.75
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestConvertScale(t *testing.T) {
+	// Test case 1: Base ten scale to natural scale
+	result := elo.ConvertScale(400, elo.CurveLogistic10, elo.CurveLogistic)
+	expectedResult := 400 / math.Ln10
+	if math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: Converted scales give the same gradient at an even match
+	c := elo.ConvertScale(400, elo.CurveLogistic10, elo.CurveGaussian)
+	result = elo.ExpGaussian(1, 0, 0, c) - elo.ExpGaussian(0, 0, 0, c)
+	expectedResult = elo.ExpProbability(1, 0, 0, 400) - elo.ExpProbability(0, 0, 0, 400)
+	if math.Abs(result-expectedResult) > 1e-8 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Converting a rating stretches its distance from the reference
+	result = elo.ConvertRating(1700, 1500, elo.CurveLogistic10, elo.CurveLogistic)
+	expectedResult = 1500 + 200*math.Ln10
+	if math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestWithExpectedFuncInverse(t *testing.T) {
+	// Test case 1: Setting a built-in expected function also sets its inverse
+	for _, expected := range []elo.Expected{elo.ExpProbability, elo.ExpLogistic, elo.ExpGaussian, elo.ExpDifference} {
+		settings := elo.New(elo.WithHomeAdvantage(0), elo.WithExpectedFunc(expected))
+		for _, difference := range []float64{-250, 0, 75} {
+			result := settings.Inverse(settings.Expected(difference, 0))
+			if math.Abs(result-difference) > 1e-6 {
+				t.Errorf(ERROR_MESSAGE, difference, result)
+			}
+		}
+	}
+
+	// Test case 2: An unknown expected function has no inverse rather than a wrong one
+	settings := elo.New(elo.WithExpectedFunc(func(rating float64, ratingOpp float64, homeAdvantage float64, c float64) float64 {
+		return 0.5
+	}))
+	if result := settings.Inverse(0.7); !math.IsNaN(result) {
+		t.Errorf("Expected NaN, but got %f", result)
+	}
+	if _, err := settings.ImpliedDifference([]float64{1.5, 3}, elo.MarginProportional); err != elo.ErrNoInverse {
+		t.Errorf("Expected %v, but got %v", elo.ErrNoInverse, err)
+	}
+
+	// Test case 3: Registered curves built from the same closure are not told apart, so neither inverse is inferred
+	power := func(base float64) elo.Curve {
+		return elo.Curve{
+			Name: fmt.Sprintf("pow%g", base),
+			Expected: func(rating float64, ratingOpp float64, homeAdvantage float64, c float64) float64 {
+				return 1 / (1 + math.Pow(base, -(rating+homeAdvantage-ratingOpp)/c))
+			},
+			Inverse: func(expected float64, c float64) float64 {
+				return c * math.Log(expected/(1-expected)) / math.Log(base)
+			},
+		}
+	}
+	pow2, pow10 := power(2), power(10)
+	elo.RegisterCurve(pow2)
+	elo.RegisterCurve(pow10)
+	settings = elo.New(elo.WithHomeAdvantage(0), elo.WithExpectedFunc(pow2.Expected))
+	if result := settings.Inverse(settings.Expected(100, 0)); !math.IsNaN(result) {
+		t.Errorf("Expected NaN, but got %f", result)
+	}
+
+	// Test case 4: A custom curve set with WithCurve keeps its own inverse
+	settings = elo.New(elo.WithHomeAdvantage(0), elo.WithCurve(pow2))
+	if result := settings.Inverse(settings.Expected(100, 0)); math.Abs(result-100) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, 100.0, result)
+	}
+}
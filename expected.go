@@ -34,11 +34,38 @@ func ExpDifference(rating float64, ratingOpp float64, homeAdvantage float64, c f
 // - ratingOpp (float64): The rating of the opposing team.
 // It returns the expected value as a float64.
 func (s *Settings) Expected(rating float64, ratingOpp float64) float64 {
+	return s.expected(rating, ratingOpp, s.homeAdvantage)
+}
+
+// Inverse calculates the rating difference for which Expected gives an expected value, using the provided inverse function, or the inverse of ExpProbability if neither function is specified.
+// It takes the following parameters:
+// - expected (float64): The expected value, such as a probability of winning.
+// It returns the rating difference (rating - ratingOpp) as a float64, net of the home advantage, or NaN if the expected function has no known inverse.
+func (s *Settings) Inverse(expected float64) float64 {
+	var inverse Inverse
+	switch {
+	case s.InverseFunc != nil:
+		inverse = *s.InverseFunc
+	case s.ExpectedFunc == nil:
+		inverse = InvLogistic10
+	default:
+		return math.NaN()
+	}
+	return inverse(expected, s.c) - s.homeAdvantage
+}
+
+// expected calculates an expected value in the same way as Expected but with an explicit home advantage, such as zero for a neutral venue.
+// It takes the following parameters:
+// - rating (float64): The rating of the subject team.
+// - ratingOpp (float64): The rating of the opposing team.
+// - homeAdvantage (float64): The home advantage factor to apply.
+// It returns the expected value as a float64.
+func (s *Settings) expected(rating float64, ratingOpp float64, homeAdvantage float64) float64 {
 	var expected Expected
 	if s.ExpectedFunc != nil {
 		expected = *s.ExpectedFunc
 	} else {
 		expected = ExpProbability
 	}
-	return expected(rating, ratingOpp, s.homeAdvantage, s.c)
+	return expected(rating, ratingOpp, homeAdvantage, s.c)
 }
@@ -0,0 +1,74 @@
+package elo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation breaks a single rating update down from the rating before the match to the rating after it.
+type Explanation struct {
+	RatingRaw     float64 // RatingRaw is the rating before the match.
+	Rating        float64 // Rating is the rating after decay, which the change is applied to.
+	RatingOppRaw  float64 // RatingOppRaw is the opposing rating before the match.
+	RatingOpp     float64 // RatingOpp is the opposing rating after decay.
+	HomeAdvantage float64 // HomeAdvantage is the home advantage added to the rating when calculating the expected value, negative for the away side.
+	Expected      float64 // Expected is the expected value of the match.
+	Observed      float64 // Observed is the observed value of the match.
+	KFactor       float64 // KFactor is the effective K-factor of the match.
+	Delta         float64 // Delta is the change given by the update function.
+	Nudge         float64 // Nudge is the move toward the market, if any.
+	Clamps        []Clamp // Clamps are the adjustments of the policies that bound the update, if any.
+	NewRating     float64 // NewRating is the rating after the match.
+}
+
+// Explain applies the match as UpdateRating does and explains the update.
+// It returns the explanation, NewRating being the value UpdateRating returns.
+func (m *Match) Explain() Explanation {
+	ratingRaw, ratingOppRaw := m.Pt.RatingRaw, m.PtOpp.RatingRaw
+	newRating := m.UpdateRating()
+	return Explanation{
+		RatingRaw:     ratingRaw,
+		Rating:        m.Pt.Rating,
+		RatingOppRaw:  ratingOppRaw,
+		RatingOpp:     m.PtOpp.Rating,
+		HomeAdvantage: m.homeAdvantage(),
+		Expected:      m.Expected,
+		Observed:      m.Observed,
+		KFactor:       m.KFactor,
+		Delta:         m.Delta,
+		Nudge:         m.Nudge,
+		Clamps:        append([]Clamp{}, m.Clamps...),
+		NewRating:     newRating,
+	}
+}
+
+// Change returns the total change from the rating before the match, decay included.
+func (e Explanation) Change() float64 {
+	return e.NewRating - e.RatingRaw
+}
+
+// String renders the explanation as human-readable text, one step per line.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rating %.2f", e.RatingRaw)
+	if e.Rating != e.RatingRaw {
+		fmt.Fprintf(&b, ", decayed to %.2f", e.Rating)
+	}
+	fmt.Fprintf(&b, "\nopponent rating %.2f", e.RatingOppRaw)
+	if e.RatingOpp != e.RatingOppRaw {
+		fmt.Fprintf(&b, ", decayed to %.2f", e.RatingOpp)
+	}
+	if e.HomeAdvantage != 0 {
+		fmt.Fprintf(&b, "\nhome advantage %+.2f", e.HomeAdvantage)
+	}
+	fmt.Fprintf(&b, "\nexpected %.4f, observed %.4f", e.Expected, e.Observed)
+	fmt.Fprintf(&b, "\nK %.2f gives a change of %+.2f", e.KFactor, e.Delta)
+	if e.Nudge != 0 {
+		fmt.Fprintf(&b, "\nmarket moves it %+.2f", e.Nudge)
+	}
+	for _, clamp := range e.Clamps {
+		fmt.Fprintf(&b, "\n%s limits %.2f to %.2f", clamp.Policy, clamp.Before, clamp.After)
+	}
+	fmt.Fprintf(&b, "\nnew rating %.2f (%+.2f)", e.NewRating, e.Change())
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package elo_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestMatchExplain(t *testing.T) {
+	m := elo.Match{
+		Pt:       elo.PlayerTeam{RatingRaw: 1700},
+		PtOpp:    elo.PlayerTeam{RatingRaw: 1400},
+		Score:    0,
+		ScoreOpp: 1,
+		Settings: elo.New(elo.WithInitRating(1500), elo.WithDecayFactor(0.5), elo.WithHomeAdvantage(100), elo.WithMaxChangeAbs(19)),
+	}
+	explanation := m.Explain()
+
+	// Test case 1: Each step of the update is recorded
+	expectedExpected := 1 / (1 + math.Pow(10, -0.75))
+	if explanation.RatingRaw != 1700 || explanation.Rating != 1600 || explanation.RatingOpp != 1400 || explanation.HomeAdvantage != 100 {
+		t.Errorf("Expected 1700 decayed to 1600 against 1400 with 100 home advantage, but got %v", explanation)
+	}
+	if math.Abs(explanation.Expected-expectedExpected) > 1e-9 || explanation.Observed != 0 || explanation.KFactor != 32 {
+		t.Errorf(ERROR_MESSAGE, expectedExpected, explanation.Expected)
+	}
+	expectedResult := -32 * expectedExpected
+	if math.Abs(explanation.Delta-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, explanation.Delta)
+	}
+
+	// Test case 2: The clamp and the result
+	if len(explanation.Clamps) != 1 || explanation.Clamps[0].Policy != elo.BoundMaxChangeAbs || explanation.Clamps[0].After != 1581 {
+		t.Errorf("Expected the absolute limit to stop the rating at 1581, but got %v", explanation.Clamps)
+	}
+	expectedResult = -119
+	if result := explanation.Change(); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: The explanation renders as text
+	text := explanation.String()
+	for _, line := range []string{
+		"rating 1700.00, decayed to 1600.00",
+		"opponent rating 1400.00",
+		"home advantage +100.00",
+		"expected 0.8490, observed 0.0000",
+		"K 32.00 gives a change of -27.17",
+		"max change abs limits 1572.83 to 1581.00",
+		"new rating 1581.00 (-119.00)",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("Expected %q in explanation, but got\n%s", line, text)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package elo
+
+import "math"
+
+const (
+	ImportanceFriendly    = "friendly"
+	ImportanceTournament  = "tournament"
+	ImportanceQualifier   = "qualifier"
+	ImportanceContinental = "continental"
+	ImportanceWorldCup    = "world cup"
+)
+
+// WorldFootballImportance returns the K multipliers of the World Football Elo ratings relative to a friendly, which
+// are played with a K of 20. Qualifiers and major tournaments use 40, continental championships 50 and World Cup
+// finals 60, other tournaments use 30.
+func WorldFootballImportance() map[string]float64 {
+	return map[string]float64{
+		ImportanceFriendly:    1,
+		ImportanceTournament:  1.5,
+		ImportanceQualifier:   2,
+		ImportanceContinental: 2.5,
+		ImportanceWorldCup:    3,
+	}
+}
+
+// KModifier is a function type that defines the signature of a K-factor modifier, scaling the K-factor of a match from its score.
+type KModifier func(score float64, scoreOpp float64) float64
+
+// KGoalDifference is a K-factor modifier from the World Football Elo ratings that grows with the margin of victory.
+// It takes the following parameters:
+// - score (float64): The score of the subject team.
+// - scoreOpp (float64): The score of the opposing team.
+// It returns 1 for a draw or a win by one goal, 1.5 for a win by two and 1.75 + (N-3)/8 for a win by N of three or more.
+func KGoalDifference(score float64, scoreOpp float64) float64 {
+	n := math.Abs(score - scoreOpp)
+	switch {
+	case n <= 1:
+		return 1
+	case n == 2:
+		return 1.5
+	}
+	return 1.75 + (n-3)/8
+}
+
+// kFactorFor calculates the effective K-factor of a match from its importance and, if configured, its score.
+// It takes the following parameters:
+// - importance (string): The importance or event type of the match, matches without a known importance use a multiplier of 1.
+// - score (float64): The score of the subject team.
+// - scoreOpp (float64): The score of the opposing team.
+// It returns the effective K-factor as a float64 value.
+func (s *Settings) kFactorFor(importance string, score float64, scoreOpp float64) float64 {
+	kFactor := s.kFactor
+	if multiplier, ok := s.Importance[importance]; ok {
+		kFactor *= multiplier
+	}
+	if s.KModifierFunc != nil {
+		kFactor *= (*s.KModifierFunc)(score, scoreOpp)
+	}
+	return kFactor
+}
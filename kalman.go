@@ -0,0 +1,154 @@
+package elo
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultKalmanVariance     float64 = 350 * 350
+	DefaultKalmanProcessNoise float64 = 40
+)
+
+// KalmanState is the estimated rating of a player and its variance at the time of their last match.
+type KalmanState struct {
+	Rating   float64
+	Variance float64
+	Time     time.Time
+}
+
+// kalmanStep is a single filter update of a player, kept for smoothing.
+type kalmanStep struct {
+	predicted KalmanState
+	filtered  KalmanState
+}
+
+// Kalman rates players with a Gaussian state space model, in which each rating follows a random walk whose variance
+// grows with the time between matches and each result is a noisy measurement of the expected value. The filtered
+// update acts like Update with a gain that adapts to how uncertain both ratings are, and the stored history can be
+// smoothed retrospectively with the Rauch-Tung-Striebel smoother.
+type Kalman struct {
+	Settings         Settings
+	ProcessNoise     float64 // ProcessNoise is the variance added to a rating for each day without a match.
+	MeasurementNoise float64 // MeasurementNoise is the variance of a result, zero using the variance of a Bernoulli trial.
+	InitVariance     float64 // InitVariance is the variance of a new rating.
+	states           map[string]KalmanState
+	history          map[string][]kalmanStep
+}
+
+// NewKalman creates a state space rater with the given process noise per day and the default variance of a new rating.
+func NewKalman(s Settings, processNoise float64) *Kalman {
+	return &Kalman{
+		Settings:     s,
+		ProcessNoise: processNoise,
+		InitVariance: DefaultKalmanVariance,
+		states:       map[string]KalmanState{},
+		history:      map[string][]kalmanStep{},
+	}
+}
+
+// State returns the current state of a player, or the seeded state of a new player if they have not played.
+func (k *Kalman) State(player string) KalmanState {
+	if state, ok := k.states[player]; ok {
+		return state
+	}
+	return KalmanState{Rating: k.Settings.SeedRating(player), Variance: k.InitVariance}
+}
+
+// predict advances the state of a player to the given time, growing the variance by the process noise.
+func (k *Kalman) predict(player string, t time.Time) KalmanState {
+	state, ok := k.states[player]
+	if !ok {
+		return KalmanState{Rating: k.Settings.SeedRating(player), Variance: k.InitVariance, Time: t}
+	}
+	if days := t.Sub(state.Time).Hours() / 24; days > 0 {
+		state.Variance += k.ProcessNoise * days
+	}
+	state.Time = t
+	return state
+}
+
+// Update applies a record to the filter, updating both players with a single measurement of the subject's observed
+// value against the expected value linearized around the current ratings.
+// It takes the following parameters:
+// - rec (Record): The result to apply, which should not be older than either player's last match.
+// It returns the new states of the subject and the opposition.
+func (k *Kalman) Update(rec Record) (KalmanState, KalmanState) {
+	if k.states == nil {
+		k.states = map[string]KalmanState{}
+		k.history = map[string][]kalmanStep{}
+	}
+	predicted := k.predict(rec.Player, rec.Time)
+	predictedOpp := k.predict(rec.PlayerOpp, rec.Time)
+
+	expected := k.Settings.Expected(predicted.Rating, predictedOpp.Rating)
+	h := k.Settings.c * 1e-4
+	slope := (k.Settings.Expected(predicted.Rating+h, predictedOpp.Rating) -
+		k.Settings.Expected(predicted.Rating-h, predictedOpp.Rating)) / (2 * h)
+	slopeOpp := (k.Settings.Expected(predicted.Rating, predictedOpp.Rating+h) -
+		k.Settings.Expected(predicted.Rating, predictedOpp.Rating-h)) / (2 * h)
+	noise := k.MeasurementNoise
+	if noise == 0 {
+		noise = math.Max(expected*(1-expected), 1e-6)
+	}
+	innovation := k.Settings.observed(rec.Score, rec.ScoreOpp) - expected
+	total := slope*slope*predicted.Variance + slopeOpp*slopeOpp*predictedOpp.Variance + noise
+
+	gain := predicted.Variance * slope / total
+	gainOpp := predictedOpp.Variance * slopeOpp / total
+	filtered := KalmanState{
+		Rating:   predicted.Rating + gain*innovation,
+		Variance: predicted.Variance * (1 - gain*slope),
+		Time:     rec.Time,
+	}
+	filteredOpp := KalmanState{
+		Rating:   predictedOpp.Rating + gainOpp*innovation,
+		Variance: predictedOpp.Variance * (1 - gainOpp*slopeOpp),
+		Time:     rec.Time,
+	}
+	k.states[rec.Player], k.states[rec.PlayerOpp] = filtered, filteredOpp
+	k.history[rec.Player] = append(k.history[rec.Player], kalmanStep{predicted: predicted, filtered: filtered})
+	k.history[rec.PlayerOpp] = append(k.history[rec.PlayerOpp], kalmanStep{predicted: predictedOpp, filtered: filteredOpp})
+	return filtered, filteredOpp
+}
+
+// Smooth runs the Rauch-Tung-Striebel smoother backwards over the stored history of every player, so that each
+// estimate also uses the results that came after it.
+// It returns the smoothed rating and its deviation after each match of each player, in time order.
+func (k *Kalman) Smooth() map[string][]TrajectoryPoint {
+	trajectories := make(map[string][]TrajectoryPoint, len(k.history))
+	for player, steps := range k.history {
+		n := len(steps)
+		rating := make([]float64, n)
+		variance := make([]float64, n)
+		rating[n-1], variance[n-1] = steps[n-1].filtered.Rating, steps[n-1].filtered.Variance
+		for i := n - 2; i >= 0; i-- {
+			next := steps[i+1].predicted
+			gain := steps[i].filtered.Variance / next.Variance
+			rating[i] = steps[i].filtered.Rating + gain*(rating[i+1]-next.Rating)
+			variance[i] = steps[i].filtered.Variance + gain*gain*(variance[i+1]-next.Variance)
+		}
+		points := make([]TrajectoryPoint, n)
+		for i, step := range steps {
+			points[i] = TrajectoryPoint{Time: step.filtered.Time, Rating: rating[i], Deviation: math.Sqrt(variance[i])}
+		}
+		trajectories[player] = points
+	}
+	return trajectories
+}
+
+// Fit filters a stored history from new ratings, in time order, and smooths it.
+// It takes the following parameters:
+// - records ([]Record): The results to fit, in any order.
+// It returns the smoothed trajectory of every player.
+func (k *Kalman) Fit(records []Record) map[string][]TrajectoryPoint {
+	k.states = map[string]KalmanState{}
+	k.history = map[string][]kalmanStep{}
+	sorted := append([]Record{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+	for _, rec := range sorted {
+		k.Update(rec)
+	}
+	return k.Smooth()
+}
@@ -0,0 +1,59 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestKalmanUpdate(t *testing.T) {
+	k := elo.NewKalman(elo.New(elo.WithInitRating(1500), elo.WithHomeAdvantage(0)), elo.DefaultKalmanProcessNoise)
+
+	// Test case 1: A win moves evenly matched players apart by the same amount
+	state, stateOpp := k.Update(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	if math.Abs((state.Rating-1500)-(1500-stateOpp.Rating)) > 0.0001 || state.Rating <= 1500 {
+		t.Errorf("Expected symmetric ratings, but got %f and %f", state.Rating, stateOpp.Rating)
+	}
+	if state.Variance >= elo.DefaultKalmanVariance {
+		t.Errorf("Expected the variance to shrink, but got %f", state.Variance)
+	}
+
+	// Test case 2: The gain falls as the ratings become more certain
+	second, _ := k.Update(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	if second.Rating-state.Rating >= state.Rating-1500 {
+		t.Errorf("Expected a smaller change than %f, but got %f", state.Rating-1500, second.Rating-state.Rating)
+	}
+
+	// Test case 3: The gain rises again with the time between matches
+	rested := elo.NewKalman(k.Settings, elo.DefaultKalmanProcessNoise)
+	rested.Update(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	later, _ := rested.Update(elo.Record{Time: day(1).AddDate(1, 0, 0), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	if later.Rating-state.Rating <= second.Rating-state.Rating {
+		t.Errorf("Expected a larger change than %f, but got %f", second.Rating-state.Rating, later.Rating-state.Rating)
+	}
+}
+
+func TestKalmanFit(t *testing.T) {
+	k := elo.NewKalman(elo.New(elo.WithInitRating(1500), elo.WithHomeAdvantage(0)), elo.DefaultKalmanProcessNoise)
+	records := []elo.Record{
+		{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1},
+		{Time: day(2), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Time: day(3), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+	}
+	result := k.Fit(records)
+
+	// Test case 1: The last smoothed estimate is the filtered estimate
+	expectedResult := k.State("a").Rating
+	if math.Abs(result["a"][2].Rating-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result["a"][2].Rating)
+	}
+
+	// Test case 2: Later wins revise the drawn first match upwards with less uncertainty
+	if result["a"][0].Rating <= 1500 {
+		t.Errorf("Expected the first rating to be above 1500, but got %f", result["a"][0].Rating)
+	}
+	if result["a"][0].Deviation >= math.Sqrt(elo.DefaultKalmanVariance) {
+		t.Errorf("Expected a deviation below the initial deviation, but got %f", result["a"][0].Deviation)
+	}
+}
@@ -0,0 +1,192 @@
+package elo
+
+import (
+	"math"
+	"sort"
+)
+
+// Massey calculates Massey ratings, the least squares fit of the point differential of every record, so that the
+// difference between two ratings is the expected margin between the players. One equation is replaced by the
+// constraint that the ratings sum to zero.
+// It takes the following parameters:
+// - records ([]Record): The results to rate, using Score and ScoreOpp as points.
+// It returns the rating of each player in points, or ErrSingular if the players are not all connected by records.
+func (s *Settings) Massey(records []Record) (map[string]float64, error) {
+	stats, players := s.tally(records, true)
+	if len(players) == 0 {
+		return map[string]float64{}, nil
+	}
+	index := make(map[string]int, len(players))
+	for i, player := range players {
+		index[player] = i
+	}
+	n := len(players)
+	m := make([][]float64, n)
+	points := make([]float64, n)
+	for i, player := range players {
+		m[i] = make([]float64, n)
+		for _, opp := range stats[player].opponents {
+			games := stats[player].games[opp]
+			m[i][i] += games
+			m[i][index[opp]] -= games
+		}
+	}
+	for _, rec := range records {
+		points[index[rec.Player]] += rec.Score - rec.ScoreOpp
+		points[index[rec.PlayerOpp]] += rec.ScoreOpp - rec.Score
+	}
+	for j := range m[n-1] {
+		m[n-1][j] = 1
+	}
+	points[n-1] = 0
+
+	x, err := solveGaussian(m, points)
+	if err != nil {
+		return nil, err
+	}
+	ratings := make(map[string]float64, n)
+	for i, player := range players {
+		ratings[player] = x[i]
+	}
+	return ratings, nil
+}
+
+// Colley calculates Colley ratings from wins and losses alone, each record counting as the observed value of a win
+// clamped between 0 and 1, so a draw is half a win. Ratings average one half and lie between 0 and 1.
+// It takes the following parameters:
+// - records ([]Record): The results to rate.
+// It returns the rating of each player, or ErrSingular if the system cannot be solved.
+func (s *Settings) Colley(records []Record) (map[string]float64, error) {
+	stats, players := s.tally(records, true)
+	index := make(map[string]int, len(players))
+	for i, player := range players {
+		index[player] = i
+	}
+	n := len(players)
+	c := make([][]float64, n)
+	b := make([]float64, n)
+	for i, player := range players {
+		c[i] = make([]float64, n)
+		c[i][i] = 2
+		b[i] = 1
+		for _, opp := range stats[player].opponents {
+			games := stats[player].games[opp]
+			wins := stats[player].wins[opp]
+			c[i][i] += games
+			c[i][index[opp]] -= games
+			b[i] += (wins - (games - wins)) / 2
+		}
+	}
+
+	x, err := solveCholesky(c, b)
+	if err != nil {
+		return nil, err
+	}
+	ratings := make(map[string]float64, n)
+	for i, player := range players {
+		ratings[player] = x[i]
+	}
+	return ratings, nil
+}
+
+// ColleyElo converts a Colley rating, the expected score against an average player, to the Elo scale by inverting the
+// configured expected function at a neutral venue around the initial rating.
+// It returns the Elo rating, or NaN if the expected function has no known inverse.
+func (s *Settings) ColleyElo(rating float64) float64 {
+	return s.InitRating + s.Inverse(rating) + s.homeAdvantage
+}
+
+// Rescale maps ratings linearly onto the mean and standard deviation of reference ratings, such as Elo ratings, over
+// the players they share, so that ratings from different methods can be read side by side.
+// It takes the following parameters:
+// - ratings (map[string]float64): The ratings to rescale.
+// - reference (map[string]float64): The ratings whose scale is matched.
+// It returns the rescaled ratings, unchanged if the players share no spread of ratings.
+func Rescale(ratings map[string]float64, reference map[string]float64) map[string]float64 {
+	var n, mean, meanRef float64
+	for player, rating := range ratings {
+		if ref, ok := reference[player]; ok {
+			n++
+			mean += rating
+			meanRef += ref
+		}
+	}
+	rescaled := make(map[string]float64, len(ratings))
+	for player, rating := range ratings {
+		rescaled[player] = rating
+	}
+	if n < 2 {
+		return rescaled
+	}
+	mean /= n
+	meanRef /= n
+	var variance, varianceRef float64
+	for player, rating := range ratings {
+		if ref, ok := reference[player]; ok {
+			variance += (rating - mean) * (rating - mean)
+			varianceRef += (ref - meanRef) * (ref - meanRef)
+		}
+	}
+	if variance == 0 {
+		return rescaled
+	}
+	scale := math.Sqrt(varianceRef / variance)
+	for player, rating := range ratings {
+		rescaled[player] = meanRef + (rating-mean)*scale
+	}
+	return rescaled
+}
+
+// Comparison is one row of a side by side comparison of rating methods, ranks starting at 1.
+type Comparison struct {
+	Player     string
+	Elo        float64
+	Massey     float64
+	Colley     float64
+	EloRank    int
+	MasseyRank int
+	ColleyRank int
+}
+
+// ranks returns the rank of each player from highest to lowest rating, ties broken by name.
+func ranks(ratings map[string]float64) map[string]int {
+	players := make([]string, 0, len(ratings))
+	for player := range ratings {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if ratings[players[i]] != ratings[players[j]] {
+			return ratings[players[i]] > ratings[players[j]]
+		}
+		return players[i] < players[j]
+	})
+	rank := make(map[string]int, len(players))
+	for i, player := range players {
+		rank[player] = i + 1
+	}
+	return rank
+}
+
+// Compare lines up Elo, Massey and Colley ratings for every player in the Elo ratings, ordered by Elo rank.
+// It takes the following parameters:
+// - elo (map[string]float64): The Elo ratings.
+// - massey (map[string]float64): The Massey ratings.
+// - colley (map[string]float64): The Colley ratings.
+// It returns one comparison per player.
+func Compare(elo map[string]float64, massey map[string]float64, colley map[string]float64) []Comparison {
+	eloRank, masseyRank, colleyRank := ranks(elo), ranks(massey), ranks(colley)
+	rows := make([]Comparison, 0, len(elo))
+	for player, rating := range elo {
+		rows = append(rows, Comparison{
+			Player:     player,
+			Elo:        rating,
+			Massey:     massey[player],
+			Colley:     colley[player],
+			EloRank:    eloRank[player],
+			MasseyRank: masseyRank[player],
+			ColleyRank: colleyRank[player],
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].EloRank < rows[j].EloRank })
+	return rows
+}
@@ -0,0 +1,91 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+var leastSquaresRecords = []elo.Record{
+	{Player: "a", PlayerOpp: "b", Score: 4, ScoreOpp: 1},
+	{Player: "b", PlayerOpp: "c", Score: 2, ScoreOpp: 1},
+	{Player: "c", PlayerOpp: "a", Score: 0, ScoreOpp: 5},
+}
+
+func TestSettingsMassey(t *testing.T) {
+	settings := elo.New()
+
+	// Test case 1: Ratings fit the point differentials and sum to zero
+	result, err := settings.Massey(leastSquaresRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"a": 8.0 / 3.0, "b": -2.0 / 3.0, "c": -2}
+	for player, expectedResult := range expected {
+		if math.Abs(result[player]-expectedResult) > 0.0001 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result[player])
+		}
+	}
+
+	// Test case 2: Disconnected players cannot be rated against each other
+	_, err = settings.Massey(append(leastSquaresRecords, elo.Record{Player: "d", PlayerOpp: "e", Score: 1}))
+	if err != elo.ErrSingular {
+		t.Errorf("Expected %v, but got %v", elo.ErrSingular, err)
+	}
+}
+
+func TestSettingsColley(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500))
+
+	// Test case 1: Ratings from wins and losses alone
+	result, err := settings.Colley(leastSquaresRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"a": 0.7, "b": 0.5, "c": 0.3}
+	for player, expectedResult := range expected {
+		if math.Abs(result[player]-expectedResult) > 0.0001 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result[player])
+		}
+	}
+
+	// Test case 2: An average Colley rating is the initial Elo rating
+	expectedResult := 1500.0
+	if rating := settings.ColleyElo(result["b"]); math.Abs(rating-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, rating)
+	}
+
+	// Test case 3: Colley ratings are converted with the configured curve, the home advantage playing no part
+	for _, curve := range []elo.Curve{elo.CurveLogistic10, elo.CurveGaussian} {
+		settings := elo.New(elo.WithInitRating(1500), elo.WithHomeAdvantage(50), elo.WithCurve(curve))
+		rating := settings.ColleyElo(result["a"])
+		if expectedResult := curve.Expected(rating, 1500, 0, elo.DefaultC); math.Abs(expectedResult-result["a"]) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, result["a"], expectedResult)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	eloRatings := settings.Replay()(leastSquaresRecords)
+	massey, _ := settings.Massey(leastSquaresRecords)
+	colley, _ := settings.Colley(leastSquaresRecords)
+
+	// Test case 1: Rows are ordered by Elo and the methods agree on the order
+	rows := elo.Compare(eloRatings, elo.Rescale(massey, eloRatings), colley)
+	for i, row := range rows {
+		if row.EloRank != i+1 || row.MasseyRank != i+1 || row.ColleyRank != i+1 {
+			t.Errorf("Expected rank %d throughout, but got %v", i+1, row)
+		}
+	}
+
+	// Test case 2: Rescaled ratings share the mean of the reference
+	mean := 0.0
+	for _, row := range rows {
+		mean += row.Massey - row.Elo
+	}
+	if math.Abs(mean) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, 0.0, mean)
+	}
+}
@@ -0,0 +1,225 @@
+package elo
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrMissingPlayer = errors.New("elo: record is missing a player")
+	ErrSamePlayer    = errors.New("elo: record has the same player on both sides")
+)
+
+// entry is a record stored in a ledger along with the ratings either side of it.
+type entry struct {
+	Record
+	pt           PlayerTeam // pt is the subject before the match.
+	ptOpp        PlayerTeam // ptOpp is the opposition before the match.
+	newRating    float64    // newRating is the subject's rating after the match.
+	newRatingOpp float64    // newRatingOpp is the opposition's rating after the match.
+	m            Match      // m is the subject's side of the match as last applied.
+	mOpp         Match      // mOpp is the opposition's side of the match as last applied.
+}
+
+// HistoryEntry is the change in a player's rating from a single match.
+type HistoryEntry struct {
+	Time     time.Time
+	ID       string
+	Before   float64 // Before is the rating before the match.
+	After    float64 // After is the rating after the match.
+	Expected float64 // Expected is the expected value of the match for the player.
+	Observed float64 // Observed is the observed value of the match for the player.
+}
+
+// RatingDiff is the change in a player's rating between two points in time.
+type RatingDiff struct {
+	Player string
+	Before float64
+	After  float64
+	Change float64
+}
+
+// Ledger holds a time ordered history of records and the ratings that result from applying them in order.
+type Ledger struct {
+	Settings Settings
+	entries  []entry
+	ratings  map[string]float64
+}
+
+// NewLedger creates an empty ledger that rates records using the given settings.
+func NewLedger(s Settings) *Ledger {
+	return &Ledger{
+		Settings: s,
+		ratings:  map[string]float64{},
+	}
+}
+
+// Insert adds a record to the ledger at the position given by its time, records with an equal time are kept in
+// insertion order. When the record is older than records already rated, only the ratings downstream of it are
+// recomputed, that is the records involving a player whose rating has changed since the insertion point.
+// It takes the following parameters:
+// - rec (Record): The result to insert.
+// It returns the sorted names of the players whose current rating changed, or an error if the record is invalid.
+func (l *Ledger) Insert(rec Record) ([]string, error) {
+	if err := rec.validate(); err != nil {
+		return nil, err
+	}
+	if l.ratings == nil {
+		l.ratings = map[string]float64{}
+	}
+
+	idx := l.upTo(rec.Time)
+	state := map[string]PlayerTeam{
+		rec.Player:    l.before(rec.Player, idx),
+		rec.PlayerOpp: l.before(rec.PlayerOpp, idx),
+	}
+	l.entries = append(l.entries, entry{})
+	copy(l.entries[idx+1:], l.entries[idx:])
+	l.entries[idx] = entry{Record: rec}
+
+	for i := idx; i < len(l.entries); i++ {
+		e := &l.entries[i]
+		pt, ok := state[e.Player]
+		ptOpp, okOpp := state[e.PlayerOpp]
+		if !ok && !okOpp {
+			continue
+		}
+		if !ok {
+			pt = e.pt
+		}
+		if !okOpp {
+			ptOpp = e.ptOpp
+		}
+		e.pt, e.ptOpp = pt, ptOpp
+		e.m, e.mOpp = matches(l.Settings, e.Record, pt, ptOpp)
+		e.newRating, e.newRatingOpp = e.m.UpdateRating(), e.mOpp.UpdateRating()
+		state[e.Player] = pt.after(e.newRating)
+		state[e.PlayerOpp] = ptOpp.after(e.newRatingOpp)
+	}
+
+	changed := []string{}
+	for player, pt := range state {
+		if current, ok := l.ratings[player]; !ok || current != pt.RatingRaw {
+			changed = append(changed, player)
+		}
+		l.ratings[player] = pt.RatingRaw
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// before finds a player immediately before the entry at the given index.
+// It takes the following parameters:
+// - player (string): The name of the player.
+// - idx (int): The index of the entry.
+// It returns the player's rating and peak after their last earlier match, or a seeded rating if they have not played.
+func (l *Ledger) before(player string, idx int) PlayerTeam {
+	for i := idx - 1; i >= 0; i-- {
+		e := l.entries[i]
+		if e.Player == player {
+			return e.pt.after(e.newRating)
+		}
+		if e.PlayerOpp == player {
+			return e.ptOpp.after(e.newRatingOpp)
+		}
+	}
+	return l.Settings.newPlayer(player)
+}
+
+// Rating returns the current rating of a player, or a seeded rating if they have not played.
+func (l *Ledger) Rating(player string) float64 {
+	if rating, ok := l.ratings[player]; ok {
+		return rating
+	}
+	return l.Settings.SeedRating(player)
+}
+
+// Games returns the number of games a player has in the ledger.
+func (l *Ledger) Games(player string) int {
+	return l.before(player, len(l.entries)).Games
+}
+
+// Provisional reports whether a player is a seeded newcomer still within the provisional period.
+func (l *Ledger) Provisional(player string) bool {
+	return l.Settings.Provisional(l.before(player, len(l.entries)))
+}
+
+// Ratings returns a copy of the current rating of every player in the ledger.
+func (l *Ledger) Ratings() map[string]float64 {
+	ratings := make(map[string]float64, len(l.ratings))
+	for player, rating := range l.ratings {
+		ratings[player] = rating
+	}
+	return ratings
+}
+
+// Records returns the records held in the ledger in time order.
+func (l *Ledger) Records() []Record {
+	records := make([]Record, len(l.entries))
+	for i, e := range l.entries {
+		records[i] = e.Record
+	}
+	return records
+}
+
+// History returns the rating history of a player in time order.
+func (l *Ledger) History(player string) []HistoryEntry {
+	var history []HistoryEntry
+	for _, e := range l.entries {
+		switch player {
+		case e.Player:
+			history = append(history, HistoryEntry{Time: e.Time, ID: e.ID, Before: e.pt.RatingRaw, After: e.newRating, Expected: e.m.Expected, Observed: e.m.Observed})
+		case e.PlayerOpp:
+			history = append(history, HistoryEntry{Time: e.Time, ID: e.ID, Before: e.ptOpp.RatingRaw, After: e.newRatingOpp, Expected: e.mOpp.Expected, Observed: e.mOpp.Observed})
+		}
+	}
+	return history
+}
+
+// RatingAt returns the rating of a player as of a point in time, including matches played at that time.
+// It takes the following parameters:
+// - player (string): The name of the player.
+// - t (time.Time): The point in time.
+// It returns the rating after the player's last match up to t, or a seeded rating if they had not played by then.
+func (l *Ledger) RatingAt(player string, t time.Time) float64 {
+	return l.before(player, l.upTo(t)).RatingRaw
+}
+
+// RatingsAt returns the rating of every player who had played as of a point in time, including matches played at that time.
+func (l *Ledger) RatingsAt(t time.Time) map[string]float64 {
+	ratings := map[string]float64{}
+	for _, e := range l.entries[:l.upTo(t)] {
+		ratings[e.Player] = e.newRating
+		ratings[e.PlayerOpp] = e.newRatingOpp
+	}
+	return ratings
+}
+
+// Diff compares the ratings of every player who had played by the later of two points in time.
+// It takes the following parameters:
+// - t1 (time.Time): The earlier point in time.
+// - t2 (time.Time): The later point in time.
+// It returns the change of each player sorted by name, players who had not played by t1 starting from a seeded rating.
+func (l *Ledger) Diff(t1 time.Time, t2 time.Time) []RatingDiff {
+	before, after := l.RatingsAt(t1), l.RatingsAt(t2)
+	diffs := make([]RatingDiff, 0, len(after))
+	for player, rating := range after {
+		ratingBefore, ok := before[player]
+		if !ok {
+			ratingBefore = l.Settings.SeedRating(player)
+		}
+		diffs = append(diffs, RatingDiff{Player: player, Before: ratingBefore, After: rating, Change: rating - ratingBefore})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Player < diffs[j].Player
+	})
+	return diffs
+}
+
+// upTo returns the number of entries played at or before a point in time.
+func (l *Ledger) upTo(t time.Time) int {
+	return sort.Search(len(l.entries), func(i int) bool {
+		return l.entries[i].Time.After(t)
+	})
+}
@@ -0,0 +1,178 @@
+package elo_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/watson-sam/elo"
+)
+
+func day(d int) time.Time {
+	return time.Date(2023, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLedgerInsert(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	records := []elo.Record{
+		{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Time: day(2), Player: "c", PlayerOpp: "d", Score: 0, ScoreOpp: 1},
+		{Time: day(3), Player: "b", PlayerOpp: "c", Score: 1, ScoreOpp: 1},
+		{Time: day(5), Player: "e", PlayerOpp: "f", Score: 2, ScoreOpp: 0},
+		{Time: day(6), Player: "a", PlayerOpp: "d", Score: 0, ScoreOpp: 1},
+	}
+	late := elo.Record{Time: day(4), Player: "a", PlayerOpp: "c", Score: 3, ScoreOpp: 1}
+
+	// Test case 1: Inserting late matches a ledger built in time order
+	inOrder := elo.NewLedger(settings)
+	for _, rec := range append(append(append([]elo.Record{}, records[:3]...), late), records[3:]...) {
+		if _, err := inOrder.Insert(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outOfOrder := elo.NewLedger(settings)
+	for _, rec := range records {
+		if _, err := outOfOrder.Insert(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := outOfOrder.Ratings()
+	changed, err := outOfOrder.Insert(late)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for player, rating := range inOrder.Ratings() {
+		result := outOfOrder.Rating(player)
+		if math.Abs(result-rating) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, rating, result)
+		}
+	}
+
+	// Test case 2: Only players reached downstream of the insertion are reported
+	expectedChanged := []string{"a", "c", "d"}
+	if !reflect.DeepEqual(changed, expectedChanged) {
+		t.Errorf("Expected %v, but got %v", expectedChanged, changed)
+	}
+	if outOfOrder.Rating("b") != before["b"] || outOfOrder.Rating("e") != before["e"] {
+		t.Errorf("Expected unaffected players to keep their ratings")
+	}
+
+	// Test case 3: Records are returned in time order
+	result := outOfOrder.Records()
+	if !result[3].Time.Equal(day(4)) {
+		t.Errorf("Expected late record at position 3, but got %v", result[3].Time)
+	}
+}
+
+func TestLedgerInsertInvalid(t *testing.T) {
+	ledger := elo.NewLedger(elo.New())
+
+	// Test case 1: Missing player
+	if _, err := ledger.Insert(elo.Record{Player: "a"}); err != elo.ErrMissingPlayer {
+		t.Errorf("Expected %v, but got %v", elo.ErrMissingPlayer, err)
+	}
+
+	// Test case 2: Same player on both sides
+	if _, err := ledger.Insert(elo.Record{Player: "a", PlayerOpp: "a"}); err != elo.ErrSamePlayer {
+		t.Errorf("Expected %v, but got %v", elo.ErrSamePlayer, err)
+	}
+}
+
+func TestLedgerHomeAdvantage(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(100), elo.WithInitRating(1500))
+
+	// Test case 1: A draw between equal players moves the home side down and the away side up by the same amount
+	ledger := elo.NewLedger(settings)
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1})
+	expectedResult := 1500 - elo.DefaultKFactor*(1/(1+math.Pow(10, -0.25))-0.5)
+	if result := ledger.Rating("a"); math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	if result := ledger.Rating("a") + ledger.Rating("b"); math.Abs(result-3000) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, 3000.0, result)
+	}
+
+	// Test case 2: The changes of both sides cancel whatever the result
+	ledger = elo.NewLedger(settings)
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	ledger.Insert(elo.Record{Time: day(2), Player: "b", PlayerOpp: "c", Score: 0, ScoreOpp: 2})
+	ledger.Insert(elo.Record{Time: day(3), Player: "c", PlayerOpp: "a", Score: 1, ScoreOpp: 1})
+	total := 0.0
+	for _, rating := range ledger.Ratings() {
+		total += rating
+	}
+	if math.Abs(total-4500) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, 4500.0, total)
+	}
+}
+
+func TestLedgerFloor(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithInitRating(1990), elo.WithFloorFunc(elo.FloorFromPeak))
+	ledger := elo.NewLedger(settings)
+
+	// Test case 1: The ledger tracks peaks so repeated losses stop at the floor
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	for d := 2; d < 30; d++ {
+		ledger.Insert(elo.Record{Time: day(d), Player: "a", PlayerOpp: fmt.Sprint(d), Score: 0, ScoreOpp: 1})
+	}
+	expectedResult := 1800.0
+	if result := ledger.Rating("a"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestLedgerHistory(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithInitRating(1500))
+	ledger := elo.NewLedger(settings)
+	ledger.Insert(elo.Record{ID: "2", Time: day(3), Player: "b", PlayerOpp: "a", Score: 1, ScoreOpp: 0})
+	ledger.Insert(elo.Record{ID: "1", Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	ledger.Insert(elo.Record{ID: "3", Time: day(5), Player: "a", PlayerOpp: "c", Score: 0, ScoreOpp: 0})
+
+	// Test case 1: History is in time order and each entry starts where the last finished
+	history := ledger.History("a")
+	if len(history) != 3 || history[0].ID != "1" || history[1].ID != "2" || history[2].ID != "3" {
+		t.Fatalf("Expected history of matches 1, 2 and 3, but got %v", history)
+	}
+	if history[0].Before != 1500 || history[0].After != 1516 || history[0].Expected != 0.5 || history[0].Observed != 1 {
+		t.Errorf("Expected a first match from 1500 to 1516 with an even expectation, but got %v", history[0])
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i].Before != history[i-1].After {
+			t.Errorf(ERROR_MESSAGE, history[i-1].After, history[i].Before)
+		}
+	}
+	if history[1].Observed != 0 || history[2].Observed != 0.5 {
+		t.Errorf("Expected observed values of 0 and 0.5, but got %f and %f", history[1].Observed, history[2].Observed)
+	}
+
+	// Test case 2: Ratings as of a date include matches on that date
+	result := ledger.RatingAt("a", day(1))
+	expectedResult := 1516.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	result = ledger.RatingAt("a", day(4))
+	expectedResult = history[1].After
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	result = ledger.RatingAt("c", day(4))
+	expectedResult = 1500
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Diffs cover every player who had played by the later date
+	diffs := ledger.Diff(day(2), day(5))
+	if len(diffs) != 3 || diffs[0].Player != "a" || diffs[2].Player != "c" {
+		t.Fatalf("Expected diffs for a, b and c, but got %v", diffs)
+	}
+	if diffs[0].Before != 1516 || diffs[0].After != history[2].After || diffs[0].Change != diffs[0].After-diffs[0].Before {
+		t.Errorf("Expected a to move from 1516 to %f, but got %v", history[2].After, diffs[0])
+	}
+	if diffs[2].Before != 1500 {
+		t.Errorf(ERROR_MESSAGE, 1500.0, diffs[2].Before)
+	}
+}
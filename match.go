@@ -1,8 +1,23 @@
 package elo
 
+import "math"
+
 type PlayerTeam struct {
 	RatingRaw float64
 	Rating    float64
+	Peak      float64 // Peak is the highest rating reached, used to calculate a floor.
+	Games     int     // Games is the number of games played, used to tell when a seeded player stops being provisional.
+	Seeded    bool    // Seeded marks a player whose starting rating came from a seeding strategy.
+}
+
+// peak returns the highest rating reached, including the current raw rating.
+func (pt PlayerTeam) peak() float64 {
+	return math.Max(pt.Peak, pt.RatingRaw)
+}
+
+// after returns the player after a match that left them on the new rating.
+func (pt PlayerTeam) after(newRating float64) PlayerTeam {
+	return PlayerTeam{RatingRaw: newRating, Peak: math.Max(pt.peak(), newRating), Games: pt.Games + 1, Seeded: pt.Seeded}
 }
 
 // decay adjusts the current rating of a team towards the init rating of the system according to a given decayFactor, it is directional and
@@ -19,12 +34,29 @@ func (pt *PlayerTeam) decay(decayFactor float64, initRating float64) {
 }
 
 type Match struct {
-	Pt       PlayerTeam
-	PtOpp    PlayerTeam
-	Score    float64
-	ScoreOpp float64
-	Settings Settings
-	Expected float64
+	Pt         PlayerTeam
+	PtOpp      PlayerTeam
+	Score      float64
+	ScoreOpp   float64
+	Importance string   // Importance is the importance or event type of the match, used to scale the K-factor.
+	Market     *float64 // Market is the de-margined expected score of the subject implied by the market, nil if there is none.
+	Away       bool     // Away marks the subject as the away side, rated with the home advantage negated and moved toward the market from the home side's view.
+	Settings   Settings
+	Expected   float64
+	Observed   float64  // Observed is the observed value of the last update.
+	KFactor    float64  // KFactor is the effective K-factor used in the last update.
+	Delta      float64  // Delta is the change given by the update function in the last update.
+	Nudge      float64  // Nudge is the move toward the market in the last update.
+	Clamps     []Clamp  // Clamps are the adjustments of the policies that bound the last update, if any.
+	Bounds     []string // Bounds names the policies that bound the last update, if any.
+}
+
+// homeAdvantage returns the home advantage of the subject, negated when the subject is the away side.
+func (m *Match) homeAdvantage() float64 {
+	if m.Away {
+		return -m.Settings.homeAdvantage
+	}
+	return m.Settings.homeAdvantage
 }
 
 // UpdateRating calculates a new rating based on the provided ratings and scores using the configured functions and settings.
@@ -38,7 +70,19 @@ func (m *Match) UpdateRating() float64 {
 	m.Pt.decay(m.Settings.DecayFactor, m.Settings.InitRating)
 	m.PtOpp.decay(m.Settings.DecayFactor, m.Settings.InitRating)
 
-	m.Expected = m.Settings.Expected(m.Pt.Rating, m.PtOpp.Rating)
-	observed := m.Settings.observed(m.Score, m.ScoreOpp)
-	return m.Settings.update(m.Pt.Rating, observed, m.Expected)
+	m.Expected = m.Settings.expected(m.Pt.Rating, m.PtOpp.Rating, m.homeAdvantage())
+	m.Observed = m.Settings.observed(m.Score, m.ScoreOpp)
+	m.KFactor = m.Settings.kFactorFor(m.Importance, m.Score, m.ScoreOpp)
+	if m.Settings.Provisional(m.Pt) {
+		m.KFactor *= m.Settings.provisionalK
+	}
+	m.Delta = m.Settings.change(m.Observed, m.Expected, m.KFactor)
+	m.Nudge = m.Settings.marketNudge(m.Pt, m.PtOpp, m.Market, m.Away)
+	var newRating float64
+	newRating, m.Clamps = m.Settings.update(m.Pt, m.Observed, m.Expected, m.KFactor, m.Nudge)
+	m.Bounds = nil
+	for _, clamp := range m.Clamps {
+		m.Bounds = append(m.Bounds, clamp.Policy)
+	}
+	return newRating
 }
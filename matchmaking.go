@@ -0,0 +1,169 @@
+package elo
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// MaxSplitPlayers is the largest number of players that Split will divide into teams.
+const MaxSplitPlayers = 20
+
+var ErrUnevenSplit = errors.New("elo: players cannot be split into two equal teams")
+
+// Waiting is a player or team waiting in a matchmaking queue.
+type Waiting struct {
+	ID     string        // ID is the name of the player or team.
+	Rating float64       // Rating is the current rating of the player or team.
+	Waited time.Duration // Waited is how long the player or team has been in the queue.
+}
+
+// Pairing is a proposed match between two waiting players or teams.
+type Pairing struct {
+	Player    Waiting
+	PlayerOpp Waiting
+	Expected  float64 // Expected is the expected value of Player at a neutral venue.
+	Quality   float64 // Quality is 1 for a perfectly balanced match falling to 0 for a certain result.
+}
+
+// Split is a proposed division of waiting players into two teams.
+type Split struct {
+	Team     []Waiting
+	TeamOpp  []Waiting
+	Expected float64 // Expected is the expected value of Team at a neutral venue.
+	Quality  float64 // Quality is 1 for a perfectly balanced match falling to 0 for a certain result.
+}
+
+// Matchmaker proposes balanced pairings from a queue of waiting players.
+type Matchmaker struct {
+	Settings Settings
+	Window   float64 // Window is the rating difference allowed for a player that has just joined the queue.
+	Widen    float64 // Widen is the rating difference added to the window for each minute waited.
+	played   map[string]map[string]bool
+}
+
+// NewMatchmaker creates a matchmaker whose search window starts at window and widens by widen for each minute waited.
+func NewMatchmaker(s Settings, window float64, widen float64) *Matchmaker {
+	return &Matchmaker{
+		Settings: s,
+		Window:   window,
+		Widen:    widen,
+		played:   map[string]map[string]bool{},
+	}
+}
+
+// quality scores how balanced a match is from the expected value of one side.
+// It takes the following parameters:
+// - expected (float64): The expected value of one side, between 0 and 1.
+// It returns 1 when the expected value is 0.5, falling linearly to 0 at either extreme.
+func quality(expected float64) float64 {
+	return 1 - math.Abs(2*expected-1)
+}
+
+// window returns the rating difference a waiting player will currently accept.
+func (mm *Matchmaker) window(w Waiting) float64 {
+	return mm.Window + mm.Widen*w.Waited.Minutes()
+}
+
+// Played records that two players have met so that they are not proposed as a rematch.
+func (mm *Matchmaker) Played(player string, playerOpp string) {
+	if mm.played == nil {
+		mm.played = map[string]map[string]bool{}
+	}
+	for _, pair := range [][2]string{{player, playerOpp}, {playerOpp, player}} {
+		if mm.played[pair[0]] == nil {
+			mm.played[pair[0]] = map[string]bool{}
+		}
+		mm.played[pair[0]][pair[1]] = true
+	}
+}
+
+// Propose pairs players from the queue, preferring the most balanced matches first. Two players may only be paired
+// when their rating difference is inside the window of at least one of them and they have not already played.
+// It takes the following parameters:
+// - queue ([]Waiting): The players waiting to be matched.
+// It returns the proposed pairings, players that could not be paired are left out.
+func (mm *Matchmaker) Propose(queue []Waiting) []Pairing {
+	candidates := []Pairing{}
+	for i := 0; i < len(queue); i++ {
+		for j := i + 1; j < len(queue); j++ {
+			w, wOpp := queue[i], queue[j]
+			if mm.played[w.ID][wOpp.ID] {
+				continue
+			}
+			if math.Abs(w.Rating-wOpp.Rating) > math.Max(mm.window(w), mm.window(wOpp)) {
+				continue
+			}
+			expected := mm.Settings.expected(w.Rating, wOpp.Rating, 0)
+			candidates = append(candidates, Pairing{
+				Player:    w,
+				PlayerOpp: wOpp,
+				Expected:  expected,
+				Quality:   quality(expected),
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Quality != candidates[j].Quality {
+			return candidates[i].Quality > candidates[j].Quality
+		}
+		return candidates[i].Player.Waited+candidates[i].PlayerOpp.Waited >
+			candidates[j].Player.Waited+candidates[j].PlayerOpp.Waited
+	})
+
+	paired := map[string]bool{}
+	pairings := []Pairing{}
+	for _, p := range candidates {
+		if paired[p.Player.ID] || paired[p.PlayerOpp.ID] {
+			continue
+		}
+		paired[p.Player.ID], paired[p.PlayerOpp.ID] = true, true
+		pairings = append(pairings, p)
+	}
+	return pairings
+}
+
+// Split divides players into two equal teams whose mean ratings give the most balanced match, searching every division.
+// It takes the following parameters:
+// - players ([]Waiting): The players to divide, an even number no larger than MaxSplitPlayers.
+// It returns the most balanced split, or an error if the players cannot be divided.
+func (mm *Matchmaker) Split(players []Waiting) (Split, error) {
+	n := len(players)
+	if n == 0 || n%2 != 0 || n > MaxSplitPlayers {
+		return Split{}, ErrUnevenSplit
+	}
+	total := 0.0
+	for _, p := range players {
+		total += p.Rating
+	}
+
+	// the first player is always placed in Team so that mirrored divisions are only searched once
+	best := Split{Quality: -1}
+	for mask := 1; mask < 1<<n; mask += 2 {
+		if bits.OnesCount(uint(mask)) != n/2 {
+			continue
+		}
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				sum += players[i].Rating
+			}
+		}
+		mean := sum / float64(n/2)
+		meanOpp := (total - sum) / float64(n/2)
+		expected := mm.Settings.expected(mean, meanOpp, 0)
+		if q := quality(expected); q > best.Quality {
+			best = Split{Expected: expected, Quality: q}
+			for i := 0; i < n; i++ {
+				if mask&(1<<i) != 0 {
+					best.Team = append(best.Team, players[i])
+				} else {
+					best.TeamOpp = append(best.TeamOpp, players[i])
+				}
+			}
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,70 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestMatchmakerPropose(t *testing.T) {
+	mm := elo.NewMatchmaker(elo.New(), 50, 25)
+	queue := []elo.Waiting{
+		{ID: "a", Rating: 1500},
+		{ID: "b", Rating: 1520},
+		{ID: "c", Rating: 1800},
+		{ID: "d", Rating: 1900, Waited: 4 * time.Minute},
+	}
+
+	// Test case 1: Close ratings are paired, the wide gap is only bridged after waiting
+	result := mm.Propose(queue)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 pairings, but got %d", len(result))
+	}
+	if result[0].Player.ID != "a" || result[0].PlayerOpp.ID != "b" {
+		t.Errorf("Expected a to play b, but got %s against %s", result[0].Player.ID, result[0].PlayerOpp.ID)
+	}
+	if result[1].Player.ID != "c" || result[1].PlayerOpp.ID != "d" {
+		t.Errorf("Expected c to play d, but got %s against %s", result[1].Player.ID, result[1].PlayerOpp.ID)
+	}
+	expectedResult := 1 - math.Abs(2*result[0].Expected-1)
+	if math.Abs(result[0].Quality-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result[0].Quality)
+	}
+
+	// Test case 2: Rematches are avoided
+	mm.Played("a", "b")
+	result = mm.Propose(queue[:3])
+	if len(result) != 0 {
+		t.Errorf("Expected no pairings, but got %d", len(result))
+	}
+}
+
+func TestMatchmakerSplit(t *testing.T) {
+	mm := elo.NewMatchmaker(elo.New(), 0, 0)
+
+	// Test case 1: Strongest and weakest players are teamed together
+	players := []elo.Waiting{
+		{ID: "a", Rating: 2000},
+		{ID: "b", Rating: 1900},
+		{ID: "c", Rating: 1600},
+		{ID: "d", Rating: 1500},
+	}
+	result, err := mm.Split(players)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Team[0].ID != "a" || result.Team[1].ID != "d" {
+		t.Errorf("Expected a and d together, but got %s and %s", result.Team[0].ID, result.Team[1].ID)
+	}
+	expectedResult := 0.5
+	if math.Abs(result.Expected-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.Expected)
+	}
+
+	// Test case 2: Odd number of players
+	if _, err := mm.Split(players[:3]); err != elo.ErrUnevenSplit {
+		t.Errorf("Expected %v, but got %v", elo.ErrUnevenSplit, err)
+	}
+}
@@ -0,0 +1,125 @@
+package elo
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrInvalidOdds = errors.New("elo: decimal odds must be greater than 1")
+	ErrMarketSize  = errors.New("elo: a market must have two outcomes, or three with a draw")
+	ErrNoInverse   = errors.New("elo: the expected function has no inverse, set one with WithInverseFunc")
+)
+
+// Margin is a function type that defines the signature of a margin removal method, turning the decimal odds of every
+// outcome in a market into probabilities that sum to 1.
+type Margin func(odds []float64) []float64
+
+// AmericanToDecimal converts American odds to decimal odds, +150 paying 2.5 and -200 paying 1.5.
+func AmericanToDecimal(american float64) float64 {
+	if american > 0 {
+		return 1 + american/100
+	}
+	return 1 - 100/american
+}
+
+// FractionalToDecimal converts fractional odds such as 5/2 to decimal odds.
+func FractionalToDecimal(numerator float64, denominator float64) float64 {
+	return 1 + numerator/denominator
+}
+
+// MarginProportional removes the bookmaker's margin by scaling the implied probabilities of every outcome equally.
+// It takes the following parameters:
+// - odds ([]float64): The decimal odds of every outcome in the market.
+// It returns the probability of each outcome.
+func MarginProportional(odds []float64) []float64 {
+	probs := make([]float64, len(odds))
+	var book float64
+	for i, o := range odds {
+		probs[i] = 1 / o
+		book += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= book
+	}
+	return probs
+}
+
+// MarginShin removes the bookmaker's margin with Shin's model, which assumes part of the market is made up of insiders
+// and so takes more of the margin from longshots than from favourites.
+// It takes the following parameters:
+// - odds ([]float64): The decimal odds of every outcome in the market.
+// It returns the probability of each outcome.
+func MarginShin(odds []float64) []float64 {
+	implied := make([]float64, len(odds))
+	var book float64
+	for i, o := range odds {
+		implied[i] = 1 / o
+		book += implied[i]
+	}
+	if book <= 1 {
+		return MarginProportional(odds)
+	}
+	shin := func(z float64) []float64 {
+		probs := make([]float64, len(implied))
+		for i, p := range implied {
+			probs[i] = (math.Sqrt(z*z+4*(1-z)*p*p/book) - z) / (2 * (1 - z))
+		}
+		return probs
+	}
+
+	// the probabilities sum to more than 1 with no insiders and fall as the share of insiders z grows
+	lo, hi := 0.0, 1.0
+	for i := 0; i < DefaultMaxIter && hi-lo > DefaultTolerance; i++ {
+		z := (lo + hi) / 2
+		var total float64
+		for _, p := range shin(z) {
+			total += p
+		}
+		if total > 1 {
+			lo = z
+		} else {
+			hi = z
+		}
+	}
+	return shin((lo + hi) / 2)
+}
+
+// ExpectedFromOdds calculates the expected score of the subject from a market, a draw counting as half a win.
+// It takes the following parameters:
+// - odds ([]float64): The decimal odds of a subject win and loss, or of a subject win, draw and loss.
+// - margin (Margin): The method used to remove the bookmaker's margin.
+// It returns the expected score of the subject, or an error if the market is invalid.
+func ExpectedFromOdds(odds []float64, margin Margin) (float64, error) {
+	if len(odds) != 2 && len(odds) != 3 {
+		return 0, ErrMarketSize
+	}
+	for _, o := range odds {
+		if !(o > 1) {
+			return 0, ErrInvalidOdds
+		}
+	}
+	probs := margin(odds)
+	if len(probs) == 3 {
+		return probs[0] + probs[1]/2, nil
+	}
+	return probs[0], nil
+}
+
+// ImpliedDifference calculates the rating difference implied by a market using the inverse expected function.
+// It takes the following parameters:
+// - odds ([]float64): The decimal odds of a subject win and loss, or of a subject win, draw and loss.
+// - margin (Margin): The method used to remove the bookmaker's margin.
+// It returns the rating difference (rating - ratingOpp), net of the home advantage, or an error if the market is invalid
+// or the expected function has no inverse.
+func (s *Settings) ImpliedDifference(odds []float64, margin Margin) (float64, error) {
+	expected, err := ExpectedFromOdds(odds, margin)
+	if err != nil {
+		return 0, err
+	}
+	difference := s.Inverse(expected)
+	if math.IsNaN(difference) {
+		return 0, ErrNoInverse
+	}
+	return difference, nil
+}
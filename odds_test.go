@@ -0,0 +1,181 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestInverse(t *testing.T) {
+	// Test case 1: Rating gap implied by a 70% win chance
+	result := elo.InvLogistic10(0.7, 400)
+	expectedResult := 147.19
+	if math.Abs(result-expectedResult) > 0.01 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: The settings inverse undoes Expected, home advantage included
+	settings := elo.New(elo.WithHomeAdvantage(50))
+	result = settings.Inverse(settings.Expected(1700, 1500))
+	expectedResult = 200
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Certain results give a large but finite difference
+	for _, inverse := range []elo.Inverse{elo.InvLogistic10, elo.InvLogistic, elo.InvGaussian} {
+		lo, hi := inverse(0, 400), inverse(1, 400)
+		if math.IsInf(lo, 0) || math.IsInf(hi, 0) || lo >= 0 || math.Abs(lo+hi) > 1e-3 {
+			t.Errorf("Expected finite symmetric differences, but got %f and %f", lo, hi)
+		}
+	}
+
+	// Test case 4: The difference curve is its own inverse
+	settings = elo.New(elo.WithHomeAdvantage(0), elo.WithExpectedFunc(elo.ExpDifference), elo.WithInverseFunc(elo.InvDifference))
+	result = settings.Inverse(settings.Expected(3, 1))
+	expectedResult = 2
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestOddsConversion(t *testing.T) {
+	// Test case 1: American odds
+	result := elo.AmericanToDecimal(150)
+	expectedResult := 2.5
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	result = elo.AmericanToDecimal(-200)
+	expectedResult = 1.5
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: Fractional odds
+	result = elo.FractionalToDecimal(5, 2)
+	expectedResult = 3.5
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestMargin(t *testing.T) {
+	odds := []float64{1.3, 5.5, 9}
+
+	// Test case 1: Both methods give probabilities summing to 1
+	for _, margin := range []elo.Margin{elo.MarginProportional, elo.MarginShin} {
+		var result float64
+		for _, p := range margin(odds) {
+			result += p
+		}
+		if math.Abs(result-1) > 1e-6 {
+			t.Errorf(ERROR_MESSAGE, 1.0, result)
+		}
+	}
+
+	// Test case 2: Shin takes more of the margin from the longshot than proportional removal
+	proportional, shin := elo.MarginProportional(odds), elo.MarginShin(odds)
+	if !(shin[0] > proportional[0] && shin[2] < proportional[2]) {
+		t.Errorf("Expected Shin to favour the favourite, but got %v against %v", shin, proportional)
+	}
+
+	// Test case 3: An even market is split evenly
+	result := elo.MarginShin([]float64{1.9, 1.9})[0]
+	expectedResult := 0.5
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestImpliedDifference(t *testing.T) {
+	settings := elo.New(elo.WithHomeAdvantage(0))
+
+	// Test case 1: A two way market without a margin
+	result, err := settings.ImpliedDifference([]float64{1.5, 3}, elo.MarginProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedResult := 400 * math.Log10(2)
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: A three way market counts the draw as half a win
+	result, err = settings.ImpliedDifference([]float64{3, 3, 3}, elo.MarginProportional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedResult = 0
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Invalid markets
+	if _, err := settings.ImpliedDifference([]float64{2}, elo.MarginShin); err != elo.ErrMarketSize {
+		t.Errorf("Expected %v, but got %v", elo.ErrMarketSize, err)
+	}
+	if _, err := settings.ImpliedDifference([]float64{1, 2}, elo.MarginShin); err != elo.ErrInvalidOdds {
+		t.Errorf("Expected %v, but got %v", elo.ErrInvalidOdds, err)
+	}
+}
+
+func TestMarketNudge(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(100), elo.WithMarketWeight(0.5))
+	market, marketOpp := 2.0/3, 1.0/3
+	m := elo.Match{
+		Pt:       elo.PlayerTeam{RatingRaw: 1500},
+		PtOpp:    elo.PlayerTeam{RatingRaw: 1500},
+		Score:    1,
+		ScoreOpp: 1,
+		Market:   &market,
+		Settings: settings,
+	}
+	mOpp := elo.Match{
+		Pt:       m.PtOpp,
+		PtOpp:    m.Pt,
+		Score:    1,
+		ScoreOpp: 1,
+		Market:   &marketOpp,
+		Away:     true,
+		Settings: settings,
+	}
+
+	// Test case 1: The home side moves half the weighted gap to the market view net of home advantage
+	m.UpdateRating()
+	mOpp.UpdateRating()
+	expectedResult := 0.5 * (400*math.Log10(2) - 100) / 2
+	if math.Abs(m.Nudge-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, m.Nudge)
+	}
+
+	// Test case 2: With home advantage the away side moves the same amount the other way, so the total is unchanged
+	if result := m.Nudge + mOpp.Nudge; math.Abs(result) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, 0.0, result)
+	}
+
+	// Test case 3: Both sides together close the weighted share of the gap in a ledger
+	ledger := elo.NewLedger(elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithInitRating(1500), elo.WithMarketWeight(1)))
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1, Market: &market})
+	result := ledger.Rating("a") - ledger.Rating("b")
+	expectedResult = 400 * math.Log10(2)
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 4: A certain home win still moves the away side, whose market is 0
+	certain := 1.0
+	ledger = elo.NewLedger(elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithInitRating(1500), elo.WithMarketWeight(0.1)))
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1, Market: &certain})
+	if result := ledger.Rating("a") + ledger.Rating("b"); math.Abs(result-3000) > 1e-6 || ledger.Rating("b") >= 1500 {
+		t.Errorf("Expected b to fall by what a gains, but got %v", ledger.Ratings())
+	}
+
+	// Test case 5: A match without a market is not nudged
+	m.Market = nil
+	m.UpdateRating()
+	if m.Nudge != 0 {
+		t.Errorf(ERROR_MESSAGE, 0.0, m.Nudge)
+	}
+}
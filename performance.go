@@ -0,0 +1,117 @@
+package elo
+
+import "math"
+
+// PerformanceCap is the largest distance of a performance rating from the average opposition rating, on the scale of
+// DefaultC, reached by a 100% or 0% score.
+const PerformanceCap float64 = 800
+
+// fideDP is the FIDE table of rating differences for each percentage score from 50% to 100%.
+var fideDP = [51]float64{
+	0, 7, 14, 21, 29, 36, 43, 50, 57, 65,
+	72, 80, 87, 95, 102, 110, 117, 125, 133, 141,
+	149, 158, 166, 175, 184, 193, 202, 211, 220, 230,
+	240, 251, 262, 273, 284, 296, 309, 322, 336, 351,
+	366, 383, 401, 422, 444, 470, 501, 538, 589, 677,
+	800,
+}
+
+// Performance summarises the results of a player over an event.
+type Performance struct {
+	Games      int     // Games is the number of games played.
+	Score      float64 // Score is the total observed value over the games.
+	Percentage float64 // Percentage is the score as a fraction of the games played.
+	AverageOpp float64 // AverageOpp is the mean rating of the opposition.
+	Rating     float64 // Rating is the rating whose expected score against the opposition equals the actual score.
+	RatingFIDE float64 // RatingFIDE is the average opposition rating plus the difference from the FIDE table.
+}
+
+// FIDEDifference returns the rating difference for a percentage score from the FIDE table, scaled by c.
+// It takes the following parameters:
+// - percentage (float64): The score as a fraction of the games played, between 0 and 1.
+// - c (float64): The scaling factor of the rating system, the table being defined for DefaultC.
+// It returns the rating difference as a float64, limited to plus or minus PerformanceCap scaled by c.
+func FIDEDifference(percentage float64, c float64) float64 {
+	p := math.Max(0, math.Min(1, percentage))
+	sign := 1.0
+	if p < 0.5 {
+		p, sign = 1-p, -1
+	}
+	return sign * fideDP[int(math.Round((p-0.5)*100))] * c / DefaultC
+}
+
+// Performance calculates the performance rating of a player from the ratings of the opposition they faced and their
+// total score, solving for the rating at which the configured expected function, evaluated at a neutral venue, gives
+// the actual score. The rating is capped at PerformanceCap, scaled by c, either side of the average opposition, which
+// is also the result of a 100% or 0% score.
+// It takes the following parameters:
+// - opponents ([]float64): The ratings of the opposition in each game.
+// - score (float64): The total observed value over the games.
+// It returns the performance summary.
+func (s *Settings) Performance(opponents []float64, score float64) Performance {
+	perf := Performance{Games: len(opponents), Score: score}
+	if len(opponents) == 0 {
+		return perf
+	}
+	for _, opp := range opponents {
+		perf.AverageOpp += opp
+	}
+	perf.AverageOpp /= float64(len(opponents))
+	perf.Percentage = score / float64(len(opponents))
+	perf.RatingFIDE = perf.AverageOpp + FIDEDifference(perf.Percentage, s.c)
+
+	total := func(rating float64) float64 {
+		sum := 0.0
+		for _, opp := range opponents {
+			sum += s.expected(rating, opp, 0)
+		}
+		return sum
+	}
+	limit := PerformanceCap * s.c / DefaultC
+	lo, hi := perf.AverageOpp-limit, perf.AverageOpp+limit
+	switch {
+	case total(lo) >= score:
+		perf.Rating = lo
+	case total(hi) <= score:
+		perf.Rating = hi
+	default:
+		for i := 0; i < 100 && hi-lo > 1e-9; i++ {
+			mid := (lo + hi) / 2
+			if total(mid) < score {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		perf.Rating = (lo + hi) / 2
+	}
+	return perf
+}
+
+// EventPerformance calculates the performance of every player in an event from its records, using the ratings the
+// players held going into the event.
+// It takes the following parameters:
+// - records ([]Record): The results of the event.
+// - ratings (map[string]float64): The rating of each player going into the event, a seeded rating being used if missing.
+// It returns the performance summary of each player.
+func (s *Settings) EventPerformance(records []Record, ratings map[string]float64) map[string]Performance {
+	rating := func(player string) float64 {
+		if r, ok := ratings[player]; ok {
+			return r
+		}
+		return s.SeedRating(player)
+	}
+	opponents := map[string][]float64{}
+	scores := map[string]float64{}
+	for _, rec := range records {
+		opponents[rec.Player] = append(opponents[rec.Player], rating(rec.PlayerOpp))
+		opponents[rec.PlayerOpp] = append(opponents[rec.PlayerOpp], rating(rec.Player))
+		scores[rec.Player] += s.observed(rec.Score, rec.ScoreOpp)
+		scores[rec.PlayerOpp] += s.observed(rec.ScoreOpp, rec.Score)
+	}
+	perfs := make(map[string]Performance, len(opponents))
+	for player, opps := range opponents {
+		perfs[player] = s.Performance(opps, scores[player])
+	}
+	return perfs
+}
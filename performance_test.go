@@ -0,0 +1,80 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestFIDEDifference(t *testing.T) {
+	// Test case 1: Two thirds of the points
+	result := elo.FIDEDifference(2.0/3.0, 400)
+	expectedResult := 125.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: Below 50% mirrors the table
+	result = elo.FIDEDifference(0.25, 400)
+	expectedResult = -193.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestSettingsPerformance(t *testing.T) {
+	settings := elo.New()
+	opponents := []float64{2000, 2000, 2000}
+
+	// Test case 1: Solved rating matches the expected function
+	result := settings.Performance(opponents, 2)
+	expectedResult := 2000 + 400*math.Log10(2)
+	if math.Abs(result.Rating-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.Rating)
+	}
+	expectedResult = 2125.0
+	if result.RatingFIDE != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.RatingFIDE)
+	}
+
+	// Test case 2: A perfect score is capped
+	result = settings.Performance(opponents, 3)
+	expectedResult = 2800.0
+	if result.Rating != expectedResult || result.RatingFIDE != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.Rating)
+	}
+
+	// Test case 3: A zero score is capped
+	result = settings.Performance(opponents, 0)
+	expectedResult = 1200.0
+	if result.Rating != expectedResult || result.RatingFIDE != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result.Rating)
+	}
+}
+
+func TestSettingsEventPerformance(t *testing.T) {
+	settings := elo.New()
+	ratings := map[string]float64{"a": 2200, "b": 2000, "c": 2000}
+	records := []elo.Record{
+		{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Player: "c", PlayerOpp: "a", Score: 1, ScoreOpp: 1},
+	}
+	result := settings.EventPerformance(records, ratings)
+
+	// Test case 1: Scores are observed from each player's side
+	expectedResult := 1.5
+	if result["a"].Score != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result["a"].Score)
+	}
+	expectedResult = 2000.0
+	if result["a"].AverageOpp != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result["a"].AverageOpp)
+	}
+
+	// Test case 2: A loss in the only game gives the lowest performance
+	expectedResult = 1400.0
+	if result["b"].Rating != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result["b"].Rating)
+	}
+}
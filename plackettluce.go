@@ -0,0 +1,170 @@
+package elo
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultPLPrior        float64 = 0.5
+	DefaultPLLearningRate float64 = 0.1
+)
+
+// Ranking is the finishing order of a contest between many entrants, such as a race. Each group holds the entrants
+// that finished in the same position, best first.
+type Ranking struct {
+	ID    string
+	Time  time.Time
+	Order [][]string
+}
+
+// stages calls f for each stage of a ranking, in which the entrants of a group are chosen from those not yet placed.
+// The last group is left out, as nothing is learnt from the order of entrants left over. Ties are handled as in
+// Breslow's approximation, each tied entrant being chosen from the same remaining entrants.
+func (r Ranking) stages(f func(chosen []string, remaining []string)) {
+	remaining := []string{}
+	for _, group := range r.Order {
+		remaining = append(remaining, group...)
+	}
+	for i := 0; i+1 < len(r.Order); i++ {
+		f(r.Order[i], remaining)
+		remaining = remaining[len(r.Order[i]):]
+	}
+}
+
+// PlackettLuce rates entrants from full finishing orders, the probability of each order being the product, position
+// by position, of the strength of the entrant placed over the total strength of those not yet placed. Strengths can be
+// fitted to a batch of rankings by the MM algorithm or updated online by stochastic gradient ascent, and are returned on
+// the scale of ExpProbability with the configured c, so that two entrants compare as they would under Elo.
+type PlackettLuce struct {
+	Settings     Settings
+	Prior        float64 // Prior is the weight of a virtual win and loss against an entrant of the initial rating.
+	LearningRate float64 // LearningRate is the step size of an online update.
+	MaxIter      int     // MaxIter is the maximum number of batch iterations.
+	Tolerance    float64 // Tolerance is the largest change in log strength at which the batch fit is considered converged.
+	strengths    map[string]float64
+}
+
+// NewPlackettLuce creates a Plackett-Luce rater with the default prior and learning rate.
+func NewPlackettLuce(s Settings) *PlackettLuce {
+	return &PlackettLuce{
+		Settings:     s,
+		Prior:        DefaultPLPrior,
+		LearningRate: DefaultPLLearningRate,
+		MaxIter:      DefaultMaxIter,
+		Tolerance:    DefaultTolerance,
+		strengths:    map[string]float64{},
+	}
+}
+
+// Fit fits the strengths of every entrant in the rankings by the MM algorithm, replacing any existing strengths. With a
+// prior the virtual games against the initial rating anchor the scale, without one the geometric mean strength is
+// placed at the initial rating.
+// It takes the following parameters:
+// - rankings ([]Ranking): The finishing orders to fit.
+// It returns the fitted rating of each entrant.
+func (pl *PlackettLuce) Fit(rankings []Ranking) map[string]float64 {
+	gamma := map[string]float64{}
+	wins := map[string]float64{}
+	for _, r := range rankings {
+		for _, group := range r.Order {
+			for _, entrant := range group {
+				gamma[entrant] = 1
+			}
+		}
+		r.stages(func(chosen []string, remaining []string) {
+			for _, entrant := range chosen {
+				wins[entrant]++
+			}
+		})
+	}
+	entrants := make([]string, 0, len(gamma))
+	for entrant := range gamma {
+		entrants = append(entrants, entrant)
+	}
+	sort.Strings(entrants)
+
+	for iter := 0; iter < pl.MaxIter; iter++ {
+		denom := map[string]float64{}
+		for _, r := range rankings {
+			r.stages(func(chosen []string, remaining []string) {
+				total := 0.0
+				for _, entrant := range remaining {
+					total += gamma[entrant]
+				}
+				for _, entrant := range remaining {
+					denom[entrant] += float64(len(chosen)) / total
+				}
+			})
+		}
+		largest := 0.0
+		next := make(map[string]float64, len(entrants))
+		logMean := 0.0
+		for _, entrant := range entrants {
+			g := (wins[entrant] + pl.Prior) / (denom[entrant] + 2*pl.Prior/(gamma[entrant]+1))
+			next[entrant] = g
+			logMean += math.Log(g)
+		}
+		// the likelihood alone only fixes ratios of strength, so only renormalize when there is no prior to anchor them
+		logMean /= float64(len(entrants))
+		if pl.Prior != 0 {
+			logMean = 0
+		}
+		for _, entrant := range entrants {
+			g := next[entrant] / math.Exp(logMean)
+			largest = math.Max(largest, math.Abs(math.Log(g)-math.Log(gamma[entrant])))
+			gamma[entrant] = g
+		}
+		if largest < pl.Tolerance {
+			break
+		}
+	}
+
+	pl.strengths = make(map[string]float64, len(entrants))
+	for _, entrant := range entrants {
+		pl.strengths[entrant] = math.Log(gamma[entrant])
+	}
+	return pl.Ratings()
+}
+
+// Update applies a single ranking by one step of stochastic gradient ascent on its log likelihood.
+// It takes the following parameters:
+// - r (Ranking): The finishing order to apply.
+func (pl *PlackettLuce) Update(r Ranking) {
+	if pl.strengths == nil {
+		pl.strengths = map[string]float64{}
+	}
+	gradient := map[string]float64{}
+	r.stages(func(chosen []string, remaining []string) {
+		total := 0.0
+		for _, entrant := range remaining {
+			total += math.Exp(pl.strengths[entrant])
+		}
+		for _, entrant := range chosen {
+			gradient[entrant]++
+		}
+		for _, entrant := range remaining {
+			gradient[entrant] -= float64(len(chosen)) * math.Exp(pl.strengths[entrant]) / total
+		}
+	})
+	for _, group := range r.Order {
+		for _, entrant := range group {
+			pl.strengths[entrant] += pl.LearningRate * gradient[entrant]
+		}
+	}
+}
+
+// Rating returns the rating of an entrant on the Elo scale, or a new rating if they have not been ranked.
+func (pl *PlackettLuce) Rating(entrant string) float64 {
+	return pl.Settings.InitRating + pl.strengths[entrant]*pl.Settings.c/math.Ln10
+}
+
+// Ratings returns the rating of every ranked entrant on the Elo scale.
+func (pl *PlackettLuce) Ratings() map[string]float64 {
+	ratings := make(map[string]float64, len(pl.strengths))
+	for entrant := range pl.strengths {
+		ratings[entrant] = pl.Rating(entrant)
+	}
+	return ratings
+}
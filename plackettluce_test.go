@@ -0,0 +1,101 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestPlackettLuceFit(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500))
+	pl := elo.NewPlackettLuce(settings)
+	pl.Prior = 0
+
+	// Test case 1: Two entrant rankings agree with pairwise Elo
+	result := pl.Fit([]elo.Ranking{
+		{Order: [][]string{{"a"}, {"b"}}},
+		{Order: [][]string{{"a"}, {"b"}}},
+		{Order: [][]string{{"a"}, {"b"}}},
+		{Order: [][]string{{"b"}, {"a"}}},
+	})
+	expectedResult := 0.75
+	prob := elo.ExpProbability(result["a"], result["b"], 0, elo.DefaultC)
+	if math.Abs(prob-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, prob)
+	}
+	expectedResult = 1500.0
+	if mean := (result["a"] + result["b"]) / 2; math.Abs(mean-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, mean)
+	}
+
+	// Test case 2: Tied entrants are rated equally below the winner
+	pl.Prior = elo.DefaultPLPrior
+	result = pl.Fit([]elo.Ranking{
+		{Order: [][]string{{"a"}, {"b", "c"}, {"d"}}},
+		{Order: [][]string{{"a"}, {"c", "b"}, {"d"}}},
+		{Order: [][]string{{"b", "c"}, {"a"}, {"d"}}},
+	})
+	if math.Abs(result["b"]-result["c"]) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, result["b"], result["c"])
+	}
+	if !(result["a"] > result["b"] && result["c"] > result["d"]) {
+		t.Errorf("Expected a above b and c above d, but got %v", result)
+	}
+}
+
+func TestPlackettLuceFitStable(t *testing.T) {
+	rankings := []elo.Ranking{
+		{Order: [][]string{{"a"}, {"b"}, {"c"}}},
+		{Order: [][]string{{"a"}, {"c"}, {"b"}}},
+		{Order: [][]string{{"b"}, {"a"}, {"c"}}},
+		{Order: [][]string{{"a"}, {"b"}}},
+	}
+	fit := func(maxIter int) map[string]float64 {
+		pl := elo.NewPlackettLuce(elo.New(elo.WithInitRating(1500)))
+		pl.MaxIter = maxIter
+		pl.Tolerance = 0
+		return pl.Fit(rankings)
+	}
+
+	// Test case 1: With a prior, more iterations leave the fitted ratings where they are
+	short, long := fit(2000), fit(4000)
+	for entrant, expectedResult := range short {
+		if result := long[entrant]; math.Abs(result-expectedResult) > 1e-6 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result)
+		}
+	}
+
+	// Test case 2: An entrant known only through the prior stays at the initial rating
+	rankings = append(rankings, elo.Ranking{Order: [][]string{{"z"}}})
+	result := fit(2000)["z"]
+	expectedResult := 1500.0
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestPlackettLuceUpdate(t *testing.T) {
+	pl := elo.NewPlackettLuce(elo.New(elo.WithInitRating(1500)))
+
+	// Test case 1: A single race moves the winner up and the last placed entrant down
+	pl.Update(elo.Ranking{Order: [][]string{{"a"}, {"b"}, {"c"}}})
+	if !(pl.Rating("a") > pl.Rating("b") && pl.Rating("b") > pl.Rating("c")) {
+		t.Errorf("Expected ratings in finishing order, but got %v", pl.Ratings())
+	}
+
+	// Test case 2: The total log strength is unchanged by an update
+	total := 0.0
+	for _, rating := range pl.Ratings() {
+		total += rating - 1500
+	}
+	if math.Abs(total) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, 0.0, total)
+	}
+
+	// Test case 3: An unranked entrant has the initial rating
+	expectedResult := 1500.0
+	if result := pl.Rating("z"); result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
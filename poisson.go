@@ -0,0 +1,210 @@
+package elo
+
+import "math"
+
+const (
+	DefaultSpread   float64 = 1600
+	DefaultMaxGoals int     = 10
+	fitRounds       int     = 20
+	goldenSteps     int     = 60
+)
+
+// ScoreMatrix holds the probability of each exact score, indexed by the goals of the subject and then the opposing team.
+type ScoreMatrix [][]float64
+
+// Result returns the probability of the subject team winning, drawing and losing.
+func (sm ScoreMatrix) Result() (float64, float64, float64) {
+	win, draw, loss := 0.0, 0.0, 0.0
+	for x, row := range sm {
+		for y, p := range row {
+			switch {
+			case x > y:
+				win += p
+			case x == y:
+				draw += p
+			default:
+				loss += p
+			}
+		}
+	}
+	return win, draw, loss
+}
+
+// Over returns the probability of the total goals being above a line, such as 2.5.
+func (sm ScoreMatrix) Over(line float64) float64 {
+	over := 0.0
+	for x, row := range sm {
+		for y, p := range row {
+			if float64(x+y) > line {
+				over += p
+			}
+		}
+	}
+	return over
+}
+
+// Under returns the probability of the total goals being below a line.
+func (sm ScoreMatrix) Under(line float64) float64 {
+	under := 0.0
+	for x, row := range sm {
+		for y, p := range row {
+			if float64(x+y) < line {
+				under += p
+			}
+		}
+	}
+	return under
+}
+
+// BothScore returns the probability of both teams scoring.
+func (sm ScoreMatrix) BothScore() float64 {
+	both := 0.0
+	for x, row := range sm {
+		for y, p := range row {
+			if x > 0 && y > 0 {
+				both += p
+			}
+		}
+	}
+	return both
+}
+
+// DixonColes turns two ratings into a probability for every exact score. The rating difference, with the home
+// advantage, splits the expected goals between the sides, the ratio of the two growing tenfold for every Spread points,
+// and the goals of each side are Poisson distributed with Dixon and Coles' correction for low scores.
+type DixonColes struct {
+	Goals         float64 // Goals is the expected number of goals of each side when the ratings are equal.
+	Spread        float64 // Spread is the rating difference over which the ratio of the expected goals grows tenfold.
+	HomeAdvantage float64 // HomeAdvantage is added to the rating of the subject team.
+	Rho           float64 // Rho is the dependence between the goals of the two sides in low scoring matches.
+	MaxGoals      int     // MaxGoals is the largest number of goals of each side in the score matrix.
+}
+
+// NewDixonColes creates a scoreline model using the home advantage of the settings and default goals and spread.
+func NewDixonColes(s Settings) DixonColes {
+	return DixonColes{
+		Goals:         DefaultGoals,
+		Spread:        DefaultSpread,
+		HomeAdvantage: s.homeAdvantage,
+		MaxGoals:      DefaultMaxGoals,
+	}
+}
+
+// ExpectedGoals returns the expected goals of the subject and the opposing team.
+func (dc DixonColes) ExpectedGoals(rating float64, ratingOpp float64) (float64, float64) {
+	half := (rating + dc.HomeAdvantage - ratingOpp) / (2 * dc.Spread)
+	return dc.Goals * math.Pow(10, half), dc.Goals * math.Pow(10, -half)
+}
+
+// tau is the Dixon-Coles adjustment to the probability of a score.
+func tau(x int, y int, goals float64, goalsOpp float64, rho float64) float64 {
+	switch {
+	case x == 0 && y == 0:
+		return 1 - goals*goalsOpp*rho
+	case x == 0 && y == 1:
+		return 1 + goals*rho
+	case x == 1 && y == 0:
+		return 1 + goalsOpp*rho
+	case x == 1 && y == 1:
+		return 1 - rho
+	}
+	return 1
+}
+
+// logPoisson returns the log probability of k events for a Poisson distribution with mean lambda.
+func logPoisson(k int, lambda float64) float64 {
+	lg, _ := math.Lgamma(float64(k) + 1)
+	return float64(k)*math.Log(lambda) - lambda - lg
+}
+
+// Matrix returns the probability of every score up to MaxGoals for each side, normalised to sum to one.
+func (dc DixonColes) Matrix(rating float64, ratingOpp float64) ScoreMatrix {
+	goals, goalsOpp := dc.ExpectedGoals(rating, ratingOpp)
+	n := dc.MaxGoals
+	if n <= 0 {
+		n = DefaultMaxGoals
+	}
+	sm := make(ScoreMatrix, n+1)
+	total := 0.0
+	for x := 0; x <= n; x++ {
+		sm[x] = make([]float64, n+1)
+		for y := 0; y <= n; y++ {
+			p := tau(x, y, goals, goalsOpp, dc.Rho) * math.Exp(logPoisson(x, goals)+logPoisson(y, goalsOpp))
+			sm[x][y] = math.Max(p, 0)
+			total += sm[x][y]
+		}
+	}
+	for _, row := range sm {
+		for y := range row {
+			row[y] /= total
+		}
+	}
+	return sm
+}
+
+// logLikelihood returns the log likelihood of the scores of a set of matches.
+func (dc DixonColes) logLikelihood(matches []Match) float64 {
+	total := 0.0
+	for _, m := range matches {
+		goals, goalsOpp := dc.ExpectedGoals(m.Pt.RatingRaw, m.PtOpp.RatingRaw)
+		x, y := int(math.Round(m.Score)), int(math.Round(m.ScoreOpp))
+		t := tau(x, y, goals, goalsOpp, dc.Rho)
+		if t <= 0 {
+			return math.Inf(-1)
+		}
+		total += math.Log(t) + logPoisson(x, goals) + logPoisson(y, goalsOpp)
+	}
+	return total
+}
+
+// goldenSection maximises a function of one variable on an interval.
+func goldenSection(f func(float64) float64, lo float64, hi float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+	a, b := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+	fa, fb := f(a), f(b)
+	for i := 0; i < goldenSteps; i++ {
+		if fa < fb {
+			lo, a, fa = a, b, fb
+			b = lo + ratio*(hi-lo)
+			fb = f(b)
+		} else {
+			hi, b, fb = b, a, fa
+			a = hi - ratio*(hi-lo)
+			fa = f(a)
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Fit fits the goals, spread, home advantage and low score correction by maximum likelihood to a history of matches,
+// taking each side's rating before the match from RatingRaw and the goals from Score and ScoreOpp. Each parameter is
+// optimised in turn over a bounded range, for a fixed number of rounds.
+// It takes the following parameters:
+// - matches ([]Match): The history to fit, as fed into Match.
+// It returns the fitted model.
+func (dc DixonColes) Fit(matches []Match) DixonColes {
+	fit := dc
+	for round := 0; round < fitRounds; round++ {
+		fit.Goals = math.Exp(goldenSection(func(v float64) float64 {
+			trial := fit
+			trial.Goals = math.Exp(v)
+			return trial.logLikelihood(matches)
+		}, math.Log(0.05), math.Log(10)))
+		fit.Spread = 1 / goldenSection(func(v float64) float64 {
+			trial := fit
+			trial.Spread = 1 / v
+			return trial.logLikelihood(matches)
+		}, 1e-6, 1e-2)
+		fit.HomeAdvantage = goldenSection(func(v float64) float64 {
+			trial := fit
+			trial.HomeAdvantage = v
+			return trial.logLikelihood(matches)
+		}, -1000, 1000)
+		fit.Rho = goldenSection(func(v float64) float64 {
+			trial := fit
+			trial.Rho = v
+			return trial.logLikelihood(matches)
+		}, -0.5, 0.5)
+	}
+	return fit
+}
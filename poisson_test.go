@@ -0,0 +1,82 @@
+package elo_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+// poisson samples a Poisson distributed count by Knuth's method.
+func poisson(rng *rand.Rand, lambda float64) float64 {
+	limit, k, p := math.Exp(-lambda), 0.0, rng.Float64()
+	for p > limit {
+		k++
+		p *= rng.Float64()
+	}
+	return k
+}
+
+func TestDixonColesMatrix(t *testing.T) {
+	dc := elo.NewDixonColes(elo.New(elo.WithHomeAdvantage(0)))
+
+	// Test case 1: Probabilities sum to one
+	sm := dc.Matrix(1600, 1500)
+	win, draw, loss := sm.Result()
+	if math.Abs(win+draw+loss-1) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, 1.0, win+draw+loss)
+	}
+	if math.Abs(sm.Over(2.5)+sm.Under(2.5)-1) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, 1.0, sm.Over(2.5)+sm.Under(2.5))
+	}
+	if win <= loss {
+		t.Errorf("Expected the stronger side to be favourite, but got %f against %f", win, loss)
+	}
+
+	// Test case 2: Without correction, evenly rated sides follow independent Poisson distributions
+	sm = dc.Matrix(1500, 1500)
+	expectedResult := math.Exp(-2 * elo.DefaultGoals)
+	if math.Abs(sm[0][0]-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, sm[0][0])
+	}
+	expectedResult = (1 - math.Exp(-elo.DefaultGoals)) * (1 - math.Exp(-elo.DefaultGoals))
+	if math.Abs(sm.BothScore()-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, sm.BothScore())
+	}
+
+	// Test case 3: A negative correction makes low scoring draws more likely
+	_, draw, _ = sm.Result()
+	dc.Rho = -0.1
+	_, corrected, _ := dc.Matrix(1500, 1500).Result()
+	if corrected <= draw {
+		t.Errorf("Expected more than %f draws, but got %f", draw, corrected)
+	}
+}
+
+func TestDixonColesFit(t *testing.T) {
+	truth := elo.DixonColes{Goals: 1.4, Spread: 1200, HomeAdvantage: 60}
+	rng := rand.New(rand.NewSource(11))
+	matches := make([]elo.Match, 1500)
+	for i := range matches {
+		m := elo.Match{
+			Pt:    elo.PlayerTeam{RatingRaw: 1300 + 400*rng.Float64()},
+			PtOpp: elo.PlayerTeam{RatingRaw: 1300 + 400*rng.Float64()},
+		}
+		goals, goalsOpp := truth.ExpectedGoals(m.Pt.RatingRaw, m.PtOpp.RatingRaw)
+		m.Score, m.ScoreOpp = poisson(rng, goals), poisson(rng, goalsOpp)
+		matches[i] = m
+	}
+
+	// Test case 1: The fitted model recovers the goals and home advantage of the simulated history
+	result := elo.NewDixonColes(elo.New(elo.WithHomeAdvantage(0))).Fit(matches)
+	if math.Abs(result.Goals-truth.Goals) > 0.1 {
+		t.Errorf(ERROR_MESSAGE, truth.Goals, result.Goals)
+	}
+	if math.Abs(result.HomeAdvantage-truth.HomeAdvantage) > 40 {
+		t.Errorf(ERROR_MESSAGE, truth.HomeAdvantage, result.HomeAdvantage)
+	}
+	if math.Abs(result.Rho) > 0.1 {
+		t.Errorf(ERROR_MESSAGE, 0.0, result.Rho)
+	}
+}
@@ -0,0 +1,159 @@
+package elo
+
+import "math"
+
+const (
+	BoundMaxChangePerc = "max change perc"
+	BoundMaxChangeAbs  = "max change abs"
+	BoundMaxGainLoss   = "max gain loss"
+	BoundMinChange     = "min change"
+	BoundFloor         = "floor"
+	BoundMinRating     = "min rating"
+	BoundMaxRating     = "max rating"
+)
+
+// Policy is a named rule applied after a rating update that may adjust the new rating. Policies run in order, each
+// receiving the rating left by the one before, and a policy is reported as binding an update when it changes the rating.
+type Policy struct {
+	Name  string
+	Apply func(pt PlayerTeam, newRating float64) float64 // Apply adjusts a new rating, pt.Rating being the rating the change was applied to.
+}
+
+// Clamp is the adjustment a policy made to a new rating.
+type Clamp struct {
+	Policy string  // Policy is the name of the policy.
+	Before float64 // Before is the new rating before the policy was applied.
+	After  float64 // After is the new rating after the policy was applied.
+}
+
+// MaxChangePercPolicy limits the change of a rating to a percentage of the rating before the update.
+func MaxChangePercPolicy(maxChangePerc float64) Policy {
+	return Policy{
+		Name: BoundMaxChangePerc,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return ApplyMaxChange(pt.Rating*(1-maxChangePerc), pt.Rating*(1+maxChangePerc), newRating)
+		},
+	}
+}
+
+// MaxChangeAbsPolicy limits the change of a rating to an absolute amount.
+func MaxChangeAbsPolicy(maxChangeAbs float64) Policy {
+	return Policy{
+		Name: BoundMaxChangeAbs,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return ApplyMaxChange(pt.Rating-maxChangeAbs, pt.Rating+maxChangeAbs, newRating)
+		},
+	}
+}
+
+// MaxGainLossPolicy limits the gain and the loss of a rating separately, so that ratings can rise faster than they fall
+// or the other way round.
+func MaxGainLossPolicy(maxGain float64, maxLoss float64) Policy {
+	return Policy{
+		Name: BoundMaxGainLoss,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return ApplyMaxChange(pt.Rating-maxLoss, pt.Rating+maxGain, newRating)
+		},
+	}
+}
+
+// MinChangePolicy raises any change smaller than minChange to minChange in the same direction, so that a result
+// always moves a rating by at least that much. Updates that leave the rating unchanged are left alone.
+func MinChangePolicy(minChange float64) Policy {
+	return Policy{
+		Name: BoundMinChange,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			change := newRating - pt.Rating
+			if change == 0 || math.Abs(change) >= minChange {
+				return newRating
+			}
+			return pt.Rating + math.Copysign(minChange, change)
+		},
+	}
+}
+
+// FloorPolicy stops a rating falling below the floor calculated from the player's peak rating. A floor never lifts a
+// rating that was already below it before the update.
+func FloorPolicy(floor Floor) Policy {
+	return Policy{
+		Name: BoundFloor,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return math.Max(newRating, math.Min(floor(pt.peak()), pt.Rating))
+		},
+	}
+}
+
+// MinRatingPolicy stops a rating falling below an absolute minimum.
+func MinRatingPolicy(minRating float64) Policy {
+	return Policy{
+		Name: BoundMinRating,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return math.Max(newRating, minRating)
+		},
+	}
+}
+
+// MaxRatingPolicy stops a rating rising above an absolute maximum.
+func MaxRatingPolicy(maxRating float64) Policy {
+	return Policy{
+		Name: BoundMaxRating,
+		Apply: func(pt PlayerTeam, newRating float64) float64 {
+			return math.Min(newRating, maxRating)
+		},
+	}
+}
+
+// stage orders the policies of the pipeline, so that the floor runs after the other policies and the absolute bounds
+// run last, whatever order the policies were added in.
+func stage(name string) int {
+	switch name {
+	case BoundFloor:
+		return 1
+	case BoundMinRating, BoundMaxRating:
+		return 2
+	}
+	return 0
+}
+
+// setPolicy replaces the policy with the same name in the pipeline, or adds it after the last policy of the same or an
+// earlier stage if there is none.
+func (s *Settings) setPolicy(p Policy) {
+	at := len(s.Policies)
+	for i := range s.Policies {
+		if s.Policies[i].Name == p.Name {
+			s.Policies[i] = p
+			return
+		}
+		if at == len(s.Policies) && stage(s.Policies[i].Name) > stage(p.Name) {
+			at = i
+		}
+	}
+	s.Policies = append(s.Policies[:at:at], append([]Policy{p}, s.Policies[at:]...)...)
+}
+
+// removePolicy removes the policy with the given name from the pipeline.
+func (s *Settings) removePolicy(name string) {
+	policies := s.Policies[:0:0]
+	for _, p := range s.Policies {
+		if p.Name != name {
+			policies = append(policies, p)
+		}
+	}
+	s.Policies = policies
+}
+
+// applyPolicies runs the policy pipeline over a new rating.
+// It takes the following parameters:
+// - pt (PlayerTeam): The player or team before the update.
+// - newRating (float64): The new rating value to be checked and possibly adjusted.
+// It returns the adjusted rating as a float64 value and the adjustments of the policies that bound it.
+func (s *Settings) applyPolicies(pt PlayerTeam, newRating float64) (float64, []Clamp) {
+	var clamps []Clamp
+	for _, p := range s.Policies {
+		if adjusted := p.Apply(pt, newRating); adjusted != newRating {
+			clamps = append(clamps, Clamp{Policy: p.Name, Before: newRating, After: adjusted})
+			newRating = adjusted
+		}
+	}
+	return newRating, clamps
+}
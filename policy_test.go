@@ -0,0 +1,105 @@
+package elo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestPolicies(t *testing.T) {
+	m := elo.Match{
+		Pt:       elo.PlayerTeam{RatingRaw: 1000},
+		PtOpp:    elo.PlayerTeam{RatingRaw: 1000},
+		Score:    1,
+		ScoreOpp: 0,
+	}
+
+	// Test case 1: Percentage and absolute limits both apply, the tighter one binding
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithMaxChangePerc(0.01), elo.WithMaxChangeAbs(5))
+	result := m.UpdateRating()
+	expectedResult := 1005.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	expectedBounds := []string{elo.BoundMaxChangePerc, elo.BoundMaxChangeAbs}
+	if !reflect.DeepEqual(m.Bounds, expectedBounds) {
+		t.Errorf("Expected %v, but got %v", expectedBounds, m.Bounds)
+	}
+
+	// Test case 2: Asymmetric limits on gains and losses
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithPolicy(elo.MaxGainLossPolicy(4, 100)))
+	result = m.UpdateRating()
+	expectedResult = 1004.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	m.Score, m.ScoreOpp = 0, 1
+	result = m.UpdateRating()
+	expectedResult = 984.0
+	if result != expectedResult || m.Bounds != nil {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: A minimum change keeps the direction of the update
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithPolicy(elo.MinChangePolicy(20)))
+	result = m.UpdateRating()
+	expectedResult = 980.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 4: Policies run in order and a custom policy is reported by name
+	round := elo.Policy{
+		Name: "round",
+		Apply: func(pt elo.PlayerTeam, newRating float64) float64 {
+			return float64(int(newRating/10) * 10)
+		},
+	}
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithPolicies(elo.MaxChangeAbsPolicy(15), round))
+	result = m.UpdateRating()
+	expectedResult = 980.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	expectedBounds = []string{elo.BoundMaxChangeAbs, "round"}
+	if !reflect.DeepEqual(m.Bounds, expectedBounds) {
+		t.Errorf("Expected %v, but got %v", expectedBounds, m.Bounds)
+	}
+
+	// Test case 5: Setting a limit to zero removes it from the pipeline
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithMaxChangeAbs(5), elo.WithMaxChangeAbs(0))
+	result = m.UpdateRating()
+	expectedResult = 984.0
+	if result != expectedResult || m.Bounds != nil {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 6: The floor and the absolute bounds run last whatever order the options are passed in
+	m.Pt = elo.PlayerTeam{RatingRaw: 5}
+	m.PtOpp = elo.PlayerTeam{RatingRaw: 500}
+	for _, settings := range []elo.Settings{
+		elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithMinRating(0), elo.WithPolicy(elo.MinChangePolicy(20))),
+		elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithPolicies(elo.MinRatingPolicy(0), elo.MinChangePolicy(20))),
+	} {
+		m.Settings = settings
+		result = m.UpdateRating()
+		expectedResult = 0.0
+		if result != expectedResult {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result)
+		}
+		expectedBounds = []string{elo.BoundMinChange, elo.BoundMinRating}
+		if !reflect.DeepEqual(m.Bounds, expectedBounds) {
+			t.Errorf("Expected %v, but got %v", expectedBounds, m.Bounds)
+		}
+	}
+	settings := elo.New(elo.WithMaxRating(3000), elo.WithFloorFunc(elo.FloorFromPeak), elo.WithMinRating(0), elo.WithPolicy(elo.MaxChangeAbsPolicy(10)))
+	var names []string
+	for _, p := range settings.Policies {
+		names = append(names, p.Name)
+	}
+	expectedNames := []string{elo.BoundMaxChangeAbs, elo.BoundFloor, elo.BoundMaxRating, elo.BoundMinRating}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected %v, but got %v", expectedNames, names)
+	}
+}
@@ -0,0 +1,74 @@
+package elo
+
+import "time"
+
+// Record is a single result between two named players or teams at a point in time.
+type Record struct {
+	ID         string    // ID is an optional identifier for the match.
+	Time       time.Time // Time is when the match was played.
+	Player     string    // Player is the name of the subject player or team.
+	PlayerOpp  string    // PlayerOpp is the name of the opposing player or team.
+	Score      float64   // Score is the score of the subject player or team.
+	ScoreOpp   float64   // ScoreOpp is the score of the opposing player or team.
+	Context    string    // Context is an optional label for the conditions of the match, such as a surface or map.
+	Importance string    // Importance is an optional importance or event type of the match, used to scale the K-factor.
+	Market     *float64  // Market is the de-margined expected score of the subject implied by the market, nil if there is none.
+}
+
+// validate checks that a record names two different players.
+func (rec Record) validate() error {
+	if rec.Player == "" || rec.PlayerOpp == "" {
+		return ErrMissingPlayer
+	}
+	if rec.Player == rec.PlayerOpp {
+		return ErrSamePlayer
+	}
+	return nil
+}
+
+// matches builds both sides of a match from a record, the second match mirroring the first from the opposition's side.
+// It takes the following parameters:
+// - s (Settings): The settings used for the update.
+// - rec (Record): The result to apply.
+// - pt (PlayerTeam): The subject player or team before the match.
+// - ptOpp (PlayerTeam): The opposing player or team before the match.
+// It returns the match of the subject and of the opposition.
+func matches(s Settings, rec Record, pt PlayerTeam, ptOpp PlayerTeam) (Match, Match) {
+	var marketOpp *float64
+	if rec.Market != nil {
+		market := 1 - *rec.Market
+		marketOpp = &market
+	}
+	m := Match{
+		Pt:         pt,
+		PtOpp:      ptOpp,
+		Score:      rec.Score,
+		ScoreOpp:   rec.ScoreOpp,
+		Importance: rec.Importance,
+		Market:     rec.Market,
+		Settings:   s,
+	}
+	mOpp := Match{
+		Pt:         ptOpp,
+		PtOpp:      pt,
+		Score:      rec.ScoreOpp,
+		ScoreOpp:   rec.Score,
+		Importance: rec.Importance,
+		Market:     marketOpp,
+		Away:       true,
+		Settings:   s,
+	}
+	return m, mOpp
+}
+
+// play applies a record to both sides of a match using the given settings.
+// It takes the following parameters:
+// - s (Settings): The settings used for the update.
+// - rec (Record): The result to apply.
+// - pt (PlayerTeam): The subject player or team before the match.
+// - ptOpp (PlayerTeam): The opposing player or team before the match.
+// It returns the updated ratings of the subject and the opposition.
+func play(s Settings, rec Record, pt PlayerTeam, ptOpp PlayerTeam) (float64, float64) {
+	m, mOpp := matches(s, rec, pt, ptOpp)
+	return m.UpdateRating(), mOpp.UpdateRating()
+}
@@ -0,0 +1,153 @@
+package elo
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrNoCovariates      = errors.New("elo: players with ratings have no covariates")
+	ErrCovariateMismatch = errors.New("elo: players have different numbers of covariates")
+)
+
+// Seed is a function type that defines the signature of a seeding strategy, giving the starting rating of a new
+// player and false if the strategy knows nothing about them.
+type Seed func(player string) (float64, bool)
+
+// SeedFromRanks seeds players from an external ranking, the top ranked player starting at best and each place lower
+// starting step points below.
+// It takes the following parameters:
+// - ranks (map[string]int): The external rank of each player, starting at 1.
+// - best (float64): The starting rating of the top ranked player.
+// - step (float64): The rating difference between adjacent ranks.
+// It returns the seeding strategy.
+func SeedFromRanks(ranks map[string]int, best float64, step float64) Seed {
+	return func(player string) (float64, bool) {
+		rank, ok := ranks[player]
+		if !ok {
+			return 0, false
+		}
+		return best - step*float64(rank-1), true
+	}
+}
+
+// SeedFromRatings seeds players from an external rating list, mapped linearly onto the scale of the reference ratings
+// over the players on both lists as in Rescale.
+// It takes the following parameters:
+// - external (map[string]float64): The external rating of each player.
+// - reference (map[string]float64): Current ratings on our scale.
+// It returns the seeding strategy.
+func SeedFromRatings(external map[string]float64, reference map[string]float64) Seed {
+	seeds := Rescale(external, reference)
+	return func(player string) (float64, bool) {
+		rating, ok := seeds[player]
+		return rating, ok
+	}
+}
+
+// SeedFromDivision seeds players at the average current rating of their division or league.
+// It takes the following parameters:
+// - divisions (map[string]string): The division of each player, new and established.
+// - ratings (map[string]float64): Current ratings of established players.
+// It returns the seeding strategy, which knows nothing about players in a division without rated players.
+func SeedFromDivision(divisions map[string]string, ratings map[string]float64) Seed {
+	totals, counts := map[string]float64{}, map[string]float64{}
+	for player, rating := range ratings {
+		if division, ok := divisions[player]; ok {
+			totals[division] += rating
+			counts[division]++
+		}
+	}
+	return func(player string) (float64, bool) {
+		division, ok := divisions[player]
+		if !ok || counts[division] == 0 {
+			return 0, false
+		}
+		return totals[division] / counts[division], true
+	}
+}
+
+// SeedFromCovariates seeds players from a linear regression on covariates, such as age or results in another
+// competition.
+// It takes the following parameters:
+// - covariates (map[string][]float64): The covariates of each player.
+// - coefs ([]float64): The intercept followed by one coefficient for each covariate, as returned by FitCovariates.
+// It returns the seeding strategy.
+func SeedFromCovariates(covariates map[string][]float64, coefs []float64) Seed {
+	return func(player string) (float64, bool) {
+		x, ok := covariates[player]
+		if !ok || len(x)+1 != len(coefs) {
+			return 0, false
+		}
+		rating := coefs[0]
+		for i, v := range x {
+			rating += coefs[i+1] * v
+		}
+		return rating, true
+	}
+}
+
+// FitCovariates fits the coefficients of a linear regression of current ratings on covariates by least squares.
+// It takes the following parameters:
+// - covariates (map[string][]float64): The covariates of each player, all of the same length.
+// - ratings (map[string]float64): Current ratings of established players.
+// It returns the intercept followed by one coefficient for each covariate, or an error if the covariates are missing,
+// uneven or have no unique fit.
+func FitCovariates(covariates map[string][]float64, ratings map[string]float64) ([]float64, error) {
+	players := make([]string, 0, len(ratings))
+	for player := range ratings {
+		if _, ok := covariates[player]; ok {
+			players = append(players, player)
+		}
+	}
+	sort.Strings(players)
+
+	var xtx [][]float64
+	var xty []float64
+	for _, player := range players {
+		x, rating := covariates[player], ratings[player]
+		row := append([]float64{1}, x...)
+		if xtx == nil {
+			xtx = make([][]float64, len(row))
+			for i := range xtx {
+				xtx[i] = make([]float64, len(row))
+			}
+			xty = make([]float64, len(row))
+		}
+		if len(row) != len(xty) {
+			return nil, ErrCovariateMismatch
+		}
+		for i := range row {
+			for j := range row {
+				xtx[i][j] += row[i] * row[j]
+			}
+			xty[i] += row[i] * rating
+		}
+	}
+	if xtx == nil {
+		return nil, ErrNoCovariates
+	}
+	return solveGaussian(xtx, xty)
+}
+
+// SeedRating gives the starting rating of a player from the seeding strategy, or a new rating if there is none or it
+// knows nothing about the player.
+func (s Settings) SeedRating(player string) float64 {
+	return s.newPlayer(player).RatingRaw
+}
+
+// newPlayer creates a player who has not played, seeded by the seeding strategy if it knows them.
+func (s Settings) newPlayer(player string) PlayerTeam {
+	if s.SeedFunc != nil {
+		if rating, ok := (*s.SeedFunc)(player); ok {
+			return PlayerTeam{RatingRaw: rating, Seeded: true}
+		}
+	}
+	return PlayerTeam{RatingRaw: s.NewRating()}
+}
+
+// Provisional reports whether a player is a seeded newcomer who has played fewer games than the provisional period,
+// whose updates have the K-factor scaled by the provisional multiplier.
+func (s Settings) Provisional(pt PlayerTeam) bool {
+	return pt.Seeded && pt.Games < s.ProvisionalGames
+}
@@ -0,0 +1,165 @@
+package elo_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestSeedStrategies(t *testing.T) {
+	// Test case 1: External ranks
+	seed := elo.SeedFromRanks(map[string]int{"a": 1, "b": 4}, 2800, 10)
+	result, _ := seed("b")
+	expectedResult := 2770.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	if _, ok := seed("c"); ok {
+		t.Errorf("Expected an unranked player to be unknown")
+	}
+
+	// Test case 2: External ratings mapped onto our scale
+	external := map[string]float64{"a": 10, "b": 20, "c": 30}
+	seed = elo.SeedFromRatings(external, map[string]float64{"a": 1500, "b": 1700})
+	result, _ = seed("c")
+	expectedResult = 1900
+	if math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Division average
+	divisions := map[string]string{"a": "one", "b": "one", "c": "two", "d": "one"}
+	seed = elo.SeedFromDivision(divisions, map[string]float64{"a": 1600, "b": 1800, "c": 1200})
+	result, _ = seed("d")
+	expectedResult = 1700
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestFitCovariates(t *testing.T) {
+	covariates := map[string][]float64{"a": {1, 2}, "b": {2, 1}, "c": {3, 5}, "d": {4, 3}, "new": {5, 0}}
+	ratings := map[string]float64{}
+	for player, x := range covariates {
+		if player != "new" {
+			ratings[player] = 1000 + 50*x[0] - 10*x[1]
+		}
+	}
+
+	// Test case 1: An exact linear relationship is recovered
+	coefs, err := elo.FitCovariates(covariates, ratings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expectedResult := range []float64{1000, 50, -10} {
+		if math.Abs(coefs[i]-expectedResult) > 1e-6 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, coefs[i])
+		}
+	}
+
+	// Test case 2: The fitted regression seeds a new player
+	result, _ := elo.SeedFromCovariates(covariates, coefs)("new")
+	expectedResult := 1250.0
+	if math.Abs(result-expectedResult) > 1e-6 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Uneven covariates
+	covariates["a"] = []float64{1}
+	if _, err := elo.FitCovariates(covariates, ratings); err != elo.ErrCovariateMismatch {
+		t.Errorf("Expected %v, but got %v", elo.ErrCovariateMismatch, err)
+	}
+}
+
+func TestLedgerSeeding(t *testing.T) {
+	settings := elo.New(
+		elo.WithDecayFactor(1),
+		elo.WithHomeAdvantage(0),
+		elo.WithInitRating(1500),
+		elo.WithSeedFunc(elo.SeedFromRanks(map[string]int{"a": 1}, 2000, 10)),
+		elo.WithProvisionalGames(2),
+	)
+	ledger := elo.NewLedger(settings)
+
+	// Test case 1: A seeded player starts from the seed, others from the initial rating
+	if result := ledger.Rating("a"); result != 2000 {
+		t.Errorf(ERROR_MESSAGE, 2000.0, result)
+	}
+	ledger.Insert(elo.Record{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	result := ledger.Rating("b")
+	expectedResult := 1500 - 32/(1+math.Pow(10, 500.0/400))
+	if math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: A provisional seeded player moves with a larger K-factor
+	result = ledger.Rating("a")
+	expectedResult = 2000 + 2*32/(1+math.Pow(10, 500.0/400))
+	if math.Abs(result-expectedResult) > 1e-9 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Only seeded players are provisional, and only until they have played enough games
+	if !ledger.Provisional("a") || ledger.Provisional("b") {
+		t.Errorf("Expected only a to be provisional after 1 game")
+	}
+	ledger.Insert(elo.Record{Time: day(2), Player: "a", PlayerOpp: "c", Score: 1, ScoreOpp: 0})
+	if ledger.Provisional("a") || ledger.Games("a") != 2 {
+		t.Errorf("Expected a to stop being provisional after 2 games, but got %d games", ledger.Games("a"))
+	}
+}
+
+func TestSeedOtherRaters(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500), elo.WithSeedFunc(elo.SeedFromRanks(map[string]int{"a": 1}, 2000, 10)))
+
+	// Test case 1: Every rater starts a seeded newcomer from the seed
+	results := []float64{
+		elo.NewKalman(settings, 1).State("a").Rating,
+		elo.NewAttackDefense(settings).Strength("a").Attack,
+		elo.NewContextual(settings, 0.5).Overall("a"),
+		elo.NewStream(settings).Rating("a"),
+	}
+	for _, result := range results {
+		if result != 2000 {
+			t.Errorf(ERROR_MESSAGE, 2000.0, result)
+		}
+	}
+
+	// Test case 2: Simulated seasons start unrated teams from the seed
+	_, final := elo.NewSimulation(settings, map[string]float64{"b": 1500}, []elo.Fixture{{Home: "a", Away: "b"}}).Season(rand.New(rand.NewSource(1)))
+	if final["a"] != 2000 {
+		t.Errorf(ERROR_MESSAGE, 2000.0, final["a"])
+	}
+
+	// Test case 3: Context raters scale the K-factor of a provisional seeded newcomer
+	settings = elo.New(elo.WithInitRating(1500), elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithProvisionalGames(5),
+		elo.WithSeedFunc(elo.SeedFromRanks(map[string]int{"a": 1}, 1500, 10)))
+	c := elo.NewContextual(settings, 0.5)
+	c.Update(elo.Record{Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0, Context: "clay"})
+	ratings := map[string]float64{"a": c.Overall("a"), "b": c.Overall("b")}
+	expectedResults := map[string]float64{"a": 1532, "b": 1484}
+	for name, expectedResult := range expectedResults {
+		if result := ratings[name]; result != expectedResult {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result)
+		}
+	}
+
+	// Test case 4: Swiss tournaments scale the K-factor of a provisional seeded newcomer
+	sw := elo.NewSwiss(settings, []elo.Entrant{{ID: "a", Rating: 1500}, {ID: "b", Rating: 1500}})
+	if _, err := sw.Pair(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Result(0, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sw.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range sw.Standings() {
+		if result := e.Rating; result != expectedResults[e.ID] {
+			t.Errorf(ERROR_MESSAGE, expectedResults[e.ID], result)
+		}
+	}
+}
@@ -5,21 +5,30 @@ const (
 	DefaultC             float64 = 400
 	DefaultHomeAdvantage float64 = 0
 	DefaultKFactor       float64 = 32
+	DefaultProvisionalK  float64 = 2
 )
 
 // Settings represents the configuration for the rating system.
 type Settings struct {
-	InitRating     float64   // initRating is the initial rating value.
-	c              float64   // c is a scaling factor affecting the steepness of the probability curve.
-	homeAdvantage  float64   // homeAdvantage is the home advantage factor (if any).
-	kFactor        float64   // kFactor is the update factor used in rating calculations.
-	DecayFactor    float64   // DecayFactor is the factor used to decay rating.
-	DecayFactorOpp float64   // DecayFactorOpp is the factor used to decay opposition rating.
-	maxChangePerc  float64   // maxChangePerc defines the maximum percentage change allowed for a rating update.
-	maxChangeAbs   float64   // maxChangeAbs defines the maximum absolute change allowed for a rating update.
-	UpdateFunc     *Update   // UpdateFunc is a user-defined update function, if specified.
-	ObservedFunc   *Observed // ObservedFunc is a user-defined observed function, if specified.
-	ExpectedFunc   *Expected // ExpectedFunc is a user-defined expected function, if specified.
+	InitRating       float64            // initRating is the initial rating value.
+	c                float64            // c is a scaling factor affecting the steepness of the probability curve.
+	homeAdvantage    float64            // homeAdvantage is the home advantage factor (if any).
+	kFactor          float64            // kFactor is the update factor used in rating calculations.
+	DecayFactor      float64            // DecayFactor is the factor used to decay rating.
+	DecayFactorOpp   float64            // DecayFactorOpp is the factor used to decay opposition rating.
+	maxChangePerc    float64            // maxChangePerc defines the maximum percentage change allowed for a rating update.
+	maxChangeAbs     float64            // maxChangeAbs defines the maximum absolute change allowed for a rating update.
+	Policies         []Policy           // Policies is the ordered pipeline of rules applied after each update.
+	Importance       map[string]float64 // Importance maps the importance of a match to a multiplier of the K-factor.
+	marketWeight     float64            // marketWeight is the share of the gap to the rating difference implied by the market closed by each update.
+	UpdateFunc       *Update            // UpdateFunc is a user-defined update function, if specified.
+	KModifierFunc    *KModifier         // KModifierFunc is a user-defined K-factor modifier, if specified.
+	SeedFunc         *Seed              // SeedFunc is a user-defined seeding strategy for new players, if specified.
+	ProvisionalGames int                // ProvisionalGames is the number of games a player is provisional for.
+	provisionalK     float64            // provisionalK multiplies the K-factor of provisional players.
+	ObservedFunc     *Observed          // ObservedFunc is a user-defined observed function, if specified.
+	ExpectedFunc     *Expected          // ExpectedFunc is a user-defined expected function, if specified.
+	InverseFunc      *Inverse           // InverseFunc is the inverse of the expected function, if specified.
 }
 
 // Option is a function type that defines a configuration option for customizing the Settings.
@@ -49,6 +58,33 @@ func WithKFactor(kFactor float64) Option {
 	}
 }
 
+// WithMarketWeight sets the share of the gap between the rating difference and the difference implied by the market
+// that is closed by each update, 0 ignoring the market and 1 moving both ratings to the market view before the result.
+func WithMarketWeight(marketWeight float64) Option {
+	return func(s *Settings) {
+		s.marketWeight = marketWeight
+	}
+}
+
+// WithImportance sets the K-factor multiplier used for matches of the given importance.
+func WithImportance(importance string, multiplier float64) Option {
+	return func(s *Settings) {
+		if s.Importance == nil {
+			s.Importance = map[string]float64{}
+		}
+		s.Importance[importance] = multiplier
+	}
+}
+
+// WithImportances sets the K-factor multipliers of several importances at once, such as WorldFootballImportance.
+func WithImportances(importances map[string]float64) Option {
+	return func(s *Settings) {
+		for importance, multiplier := range importances {
+			WithImportance(importance, multiplier)(s)
+		}
+	}
+}
+
 func WithDecayFactor(decayFactor float64) Option {
 	return func(s *Settings) {
 		s.DecayFactor = decayFactor
@@ -64,12 +100,59 @@ func WithDecayFactorOpp(decayFactorOpp float64) Option {
 func WithMaxChangePerc(maxChangePerc float64) Option {
 	return func(s *Settings) {
 		s.maxChangePerc = maxChangePerc
+		if maxChangePerc == 0 {
+			s.removePolicy(BoundMaxChangePerc)
+			return
+		}
+		s.setPolicy(MaxChangePercPolicy(maxChangePerc))
 	}
 }
 
 func WithMaxChangeAbs(maxChangeAbs float64) Option {
 	return func(s *Settings) {
 		s.maxChangeAbs = maxChangeAbs
+		if maxChangeAbs == 0 {
+			s.removePolicy(BoundMaxChangeAbs)
+			return
+		}
+		s.setPolicy(MaxChangeAbsPolicy(maxChangeAbs))
+	}
+}
+
+func WithMinRating(minRating float64) Option {
+	return func(s *Settings) {
+		s.setPolicy(MinRatingPolicy(minRating))
+	}
+}
+
+func WithMaxRating(maxRating float64) Option {
+	return func(s *Settings) {
+		s.setPolicy(MaxRatingPolicy(maxRating))
+	}
+}
+
+func WithFloorFunc(floor Floor) Option {
+	return func(s *Settings) {
+		s.setPolicy(FloorPolicy(floor))
+	}
+}
+
+// WithPolicy adds a policy to the end of the pipeline, ahead of the floor and the absolute bounds, or replaces the policy
+// with the same name where it stands.
+func WithPolicy(policy Policy) Option {
+	return func(s *Settings) {
+		s.setPolicy(policy)
+	}
+}
+
+// WithPolicies replaces the whole pipeline with the given policies, in order apart from the floor and the absolute
+// bounds, which always run last.
+func WithPolicies(policies ...Policy) Option {
+	return func(s *Settings) {
+		s.Policies = nil
+		for _, p := range policies {
+			s.setPolicy(p)
+		}
 	}
 }
 
@@ -79,9 +162,26 @@ func WithObservedFunc(observed Observed) Option {
 	}
 }
 
+// WithExpectedFunc sets the expected function along with its inverse when it is one of the named built-in functions,
+// otherwise the inverse is cleared until set with WithInverseFunc. Use WithCurve to set a custom curve with its inverse.
 func WithExpectedFunc(expeced Expected) Option {
 	return func(s *Settings) {
 		s.ExpectedFunc = &expeced
+		s.InverseFunc = inverseOf(expeced)
+	}
+}
+
+func WithInverseFunc(inverse Inverse) Option {
+	return func(s *Settings) {
+		s.InverseFunc = &inverse
+	}
+}
+
+// WithCurve sets the expected function along with its inverse from a curve.
+func WithCurve(curve Curve) Option {
+	return func(s *Settings) {
+		s.ExpectedFunc = &curve.Expected
+		s.InverseFunc = &curve.Inverse
 	}
 }
 
@@ -91,21 +191,50 @@ func WithUpdateFunc(update Update) Option {
 	}
 }
 
+func WithKModifierFunc(kModifier KModifier) Option {
+	return func(s *Settings) {
+		s.KModifierFunc = &kModifier
+	}
+}
+
+func WithSeedFunc(seed Seed) Option {
+	return func(s *Settings) {
+		s.SeedFunc = &seed
+	}
+}
+
+func WithProvisionalGames(provisionalGames int) Option {
+	return func(s *Settings) {
+		s.ProvisionalGames = provisionalGames
+	}
+}
+
+// WithProvisionalK sets the multiplier of the K-factor for seeded players while they are provisional, so that a
+// seed that turns out to be wrong is corrected quickly.
+func WithProvisionalK(provisionalK float64) Option {
+	return func(s *Settings) {
+		s.provisionalK = provisionalK
+	}
+}
+
 // New creates a new Settings configuration with optional customizations using functional options.
 // It takes one or more Option functions to customize the Settings.
 func New(opts ...Option) Settings {
 	var obs Observed = ObsWinLooseDraw
 	var exp Expected = ExpProbability
 	var up Update = UpdateExpected
+	var inv Inverse = InvLogistic10
 	m := Settings{
 		InitRating:    DefaultInitRating,
 		kFactor:       DefaultKFactor,
+		provisionalK:  DefaultProvisionalK,
 		c:             DefaultC,
 		homeAdvantage: DefaultC,
 		maxChangePerc: 0,
 		maxChangeAbs:  0,
 		ObservedFunc:  &obs,
 		ExpectedFunc:  &exp,
+		InverseFunc:   &inv,
 		UpdateFunc:    &up,
 	}
 	for _, o := range opts {
@@ -0,0 +1,231 @@
+package elo
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	DefaultRuns       int     = 10000
+	DefaultPointsWin  float64 = 3
+	DefaultPointsDraw float64 = 1
+)
+
+// Fixture is a scheduled match between a home and an away team.
+type Fixture struct {
+	Home    string
+	Away    string
+	Neutral bool // Neutral removes the home advantage for the match.
+}
+
+// Positions maps each team to the probability of finishing in each position, index 0 being first place.
+type Positions map[string][]float64
+
+// Top returns the probability of a team finishing in the first n positions, such as winning the title when n is 1.
+func (p Positions) Top(team string, n int) float64 {
+	total := 0.0
+	for i, prob := range p[team] {
+		if i < n {
+			total += prob
+		}
+	}
+	return total
+}
+
+// Bottom returns the probability of a team finishing in the last n positions, such as being relegated.
+func (p Positions) Bottom(team string, n int) float64 {
+	total := 0.0
+	for i, prob := range p[team] {
+		if i >= len(p[team])-n {
+			total += prob
+		}
+	}
+	return total
+}
+
+// Simulation samples the results of a list of fixtures many times to estimate the distribution of final positions.
+// With Update set, simulated matches are rated straight through the update function with the K-factor of the score, so
+// decay, importance, the provisional multiplier and the policy pipeline of the settings are not applied to them.
+type Simulation struct {
+	Settings   Settings
+	Ratings    map[string]float64 // Ratings holds the current rating of each team.
+	Points     map[string]float64 // Points holds the points each team has already won, if the season is in progress.
+	Fixtures   []Fixture
+	Runs       int     // Runs is the number of seasons to simulate.
+	Seed       int64   // Seed is the seed of the first run, each run uses its own seed so results do not depend on Workers.
+	Workers    int     // Workers is the number of seasons simulated in parallel.
+	DrawRate   float64 // DrawRate is the probability of a draw between evenly matched teams, zero meaning no draws.
+	Update     bool    // Update rates the teams after every simulated match, with equal and opposite changes, so later fixtures use the new ratings.
+	PointsWin  float64
+	PointsDraw float64
+}
+
+// NewSimulation creates a simulation of the fixtures from the current ratings, with default runs, workers and points.
+func NewSimulation(s Settings, ratings map[string]float64, fixtures []Fixture) Simulation {
+	return Simulation{
+		Settings:   s,
+		Ratings:    ratings,
+		Fixtures:   fixtures,
+		Runs:       DefaultRuns,
+		Workers:    runtime.NumCPU(),
+		PointsWin:  DefaultPointsWin,
+		PointsDraw: DefaultPointsDraw,
+	}
+}
+
+// teams returns the sorted names of every team in the ratings, points or fixtures.
+func (sim Simulation) teams() []string {
+	seen := map[string]bool{}
+	for team := range sim.Ratings {
+		seen[team] = true
+	}
+	for team := range sim.Points {
+		seen[team] = true
+	}
+	for _, f := range sim.Fixtures {
+		seen[f.Home], seen[f.Away] = true, true
+	}
+	teams := make([]string, 0, len(seen))
+	for team := range seen {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+// sample draws a result from the expected value of the home team, splitting off a share for the draw that is largest
+// for evenly matched teams so that the expected value is unchanged.
+// It takes the following parameters:
+// - rng (*rand.Rand): The random source.
+// - expected (float64): The expected value of the home team, between 0 and 1.
+// It returns the score of the home team and of the away team.
+func (sim Simulation) sample(rng *rand.Rand, expected float64) (float64, float64) {
+	expected = math.Max(0, math.Min(1, expected))
+	draw := sim.DrawRate * 2 * math.Min(expected, 1-expected)
+	u := rng.Float64()
+	if u < expected-draw/2 {
+		return 1, 0
+	}
+	if u < expected+draw/2 {
+		return 0, 0
+	}
+	return 0, 1
+}
+
+// Season simulates a single season.
+// It takes the following parameters:
+// - rng (*rand.Rand): The random source.
+// It returns the teams in finishing order and their ratings at the end of the season.
+func (sim Simulation) Season(rng *rand.Rand) ([]string, map[string]float64) {
+	return sim.season(rng, sim.teams())
+}
+
+// season simulates one season and returns the teams in finishing order and their final ratings.
+func (sim Simulation) season(rng *rand.Rand, teams []string) ([]string, map[string]float64) {
+	ratings := make(map[string]float64, len(teams))
+	points := make(map[string]float64, len(teams))
+	for _, team := range teams {
+		rating, ok := sim.Ratings[team]
+		if !ok {
+			rating = sim.Settings.SeedRating(team)
+		}
+		ratings[team] = rating
+		points[team] = sim.Points[team]
+	}
+
+	for _, f := range sim.Fixtures {
+		homeAdvantage := sim.Settings.homeAdvantage
+		if f.Neutral {
+			homeAdvantage = 0
+		}
+		expected := sim.Settings.expected(ratings[f.Home], ratings[f.Away], homeAdvantage)
+		score, scoreOpp := sim.sample(rng, expected)
+		switch {
+		case score > scoreOpp:
+			points[f.Home] += sim.PointsWin
+		case score < scoreOpp:
+			points[f.Away] += sim.PointsWin
+		default:
+			points[f.Home] += sim.PointsDraw
+			points[f.Away] += sim.PointsDraw
+		}
+		if sim.Update {
+			// rate from the expected value the result was sampled from, so the venue is the same and the changes cancel,
+			// skipping the policies which would not keep them equal and opposite
+			observed := sim.Settings.observed(score, scoreOpp)
+			change := sim.Settings.change(observed, expected, sim.Settings.kFactorFor("", score, scoreOpp))
+			ratings[f.Home] += change
+			ratings[f.Away] -= change
+		}
+	}
+
+	// shuffle before sorting so teams level on points are ordered at random
+	order := append([]string{}, teams...)
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	sort.SliceStable(order, func(i, j int) bool {
+		return points[order[i]] > points[order[j]]
+	})
+	return order, ratings
+}
+
+// Run simulates the seasons in parallel and returns the probability of each team finishing in each position.
+func (sim Simulation) Run() Positions {
+	teams := sim.teams()
+	runs := sim.Runs
+	if runs <= 0 {
+		runs = DefaultRuns
+	}
+	workers := sim.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	counts := make(map[string][]int, len(teams))
+	for _, team := range teams {
+		counts[team] = make([]int, len(teams))
+	}
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make(map[string][]int, len(teams))
+			for _, team := range teams {
+				local[team] = make([]int, len(teams))
+			}
+			for run := range jobs {
+				rng := rand.New(rand.NewSource(sim.Seed + int64(run)))
+				order, _ := sim.season(rng, teams)
+				for pos, team := range order {
+					local[team][pos]++
+				}
+			}
+			mu.Lock()
+			for team, c := range local {
+				for pos, n := range c {
+					counts[team][pos] += n
+				}
+			}
+			mu.Unlock()
+		}()
+	}
+	for run := 0; run < runs; run++ {
+		jobs <- run
+	}
+	close(jobs)
+	wg.Wait()
+
+	positions := make(Positions, len(teams))
+	for team, c := range counts {
+		positions[team] = make([]float64, len(teams))
+		for pos, n := range c {
+			positions[team][pos] = float64(n) / float64(runs)
+		}
+	}
+	return positions
+}
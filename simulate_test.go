@@ -0,0 +1,83 @@
+package elo_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestSimulationRun(t *testing.T) {
+	ratings := map[string]float64{"a": 1800, "b": 1600, "c": 1500, "d": 1400}
+	fixtures := []elo.Fixture{}
+	for home := range ratings {
+		for away := range ratings {
+			if home != away {
+				fixtures = append(fixtures, elo.Fixture{Home: home, Away: away})
+			}
+		}
+	}
+	sim := elo.NewSimulation(elo.New(elo.WithHomeAdvantage(50), elo.WithDecayFactor(1)), ratings, fixtures)
+	sim.Runs = 2000
+	sim.Seed = 7
+	sim.DrawRate = 0.25
+	sim.Update = true
+
+	// Test case 1: Each team's positions and each position's teams sum to one
+	sim.Workers = 1
+	result := sim.Run()
+	for pos := 0; pos < 4; pos++ {
+		total := 0.0
+		for team := range ratings {
+			total += result[team][pos]
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, 1.0, total)
+		}
+	}
+	for team := range ratings {
+		total := result.Top(team, 4)
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, 1.0, total)
+		}
+	}
+
+	// Test case 2: The strongest team is the title favourite and the weakest the relegation favourite
+	if result.Top("a", 1) <= result.Top("b", 1) {
+		t.Errorf("Expected a to be title favourite, but got %f against %f", result.Top("a", 1), result.Top("b", 1))
+	}
+	if result.Bottom("d", 1) <= result.Bottom("c", 1) {
+		t.Errorf("Expected d to be relegation favourite, but got %f against %f", result.Bottom("d", 1), result.Bottom("c", 1))
+	}
+
+	// Test case 3: Seeded results do not depend on the number of workers
+	sim.Workers = 4
+	if parallel := sim.Run(); !reflect.DeepEqual(result, parallel) {
+		t.Errorf("Expected %v, but got %v", result, parallel)
+	}
+}
+
+func TestSimulationSeasonUpdate(t *testing.T) {
+	ratings := map[string]float64{"a": 1800, "b": 1600, "c": 1500}
+	sim := elo.NewSimulation(elo.New(elo.WithHomeAdvantage(100)), ratings, []elo.Fixture{
+		{Home: "a", Away: "b"},
+		{Home: "b", Away: "c", Neutral: true},
+		{Home: "c", Away: "a"},
+		{Home: "a", Away: "c", Neutral: true},
+	})
+	sim.Update = true
+	sim.DrawRate = 0.3
+
+	// Test case 1: Updates are equal and opposite, home and neutral, so the rating total is unchanged
+	rng := rand.New(rand.NewSource(1))
+	for run := 0; run < 50; run++ {
+		_, final := sim.Season(rng)
+		result := final["a"] + final["b"] + final["c"]
+		expectedResult := 4900.0
+		if math.Abs(result-expectedResult) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package elo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Change is a rating change event for one side of a record.
+type Change struct {
+	ID        string    // ID is the identifier of the record.
+	Time      time.Time // Time is when the match was played.
+	Player    string    // Player is the name of the player whose rating changed.
+	PlayerOpp string    // PlayerOpp is the name of the opposing player.
+	Before    float64   // Before is the rating before the match.
+	After     float64   // After is the rating after the match.
+	Expected  float64   // Expected is the expected value of the match for the player.
+	KFactor   float64   // KFactor is the effective K-factor of the update.
+	Bounds    []string  // Bounds names the policies that bound the update, if any.
+}
+
+// Stream rates a live stream of records, keeping the current rating and peak of every player it has seen.
+type Stream struct {
+	Settings  Settings
+	OnInvalid func(rec Record, err error) // OnInvalid is called with records that are skipped as invalid, if specified.
+	mu        sync.Mutex
+	players   map[string]PlayerTeam
+}
+
+// NewStream creates a stream processor that rates records using the given settings.
+func NewStream(s Settings) *Stream {
+	return &Stream{
+		Settings: s,
+		players:  map[string]PlayerTeam{},
+	}
+}
+
+// Run reads records from in and applies each to both sides as Match.UpdateRating does, sending the change of the
+// subject and then of the opposition to out before reading the next record. Records are rated and their events sent in
+// the order they arrive, so the events of each player are in the order of their matches, and a slow reader holds back
+// the stream. The new ratings of a record are only stored once both of its events have been sent.
+// It takes the following parameters:
+// - ctx (context.Context): Stops the stream when cancelled, a record whose events were not both sent being left unrated.
+// - in (<-chan Record): The records to rate, closed by the caller when the stream is finished.
+// - out (chan<- Change): The rating change events.
+// It returns nil once in is closed and every record has been rated and sent, or the error of the context.
+func (st *Stream) Run(ctx context.Context, in <-chan Record, out chan<- Change) error {
+	defer close(out)
+	for {
+		var rec Record
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case rec, ok = <-in:
+			if !ok {
+				return nil
+			}
+		}
+		if err := rec.validate(); err != nil {
+			if st.OnInvalid != nil {
+				st.OnInvalid(rec, err)
+			}
+			continue
+		}
+		changes, pt, ptOpp := st.rate(rec)
+		for _, change := range changes {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case out <- change:
+			}
+		}
+		st.store(rec, pt, ptOpp)
+	}
+}
+
+// rate rates a record without storing the new ratings.
+// It takes the following parameters:
+// - rec (Record): The result to apply.
+// It returns the changes of the subject and of the opposition, and both players after the match.
+func (st *Stream) rate(rec Record) ([2]Change, PlayerTeam, PlayerTeam) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	pt, ptOpp := st.player(rec.Player), st.player(rec.PlayerOpp)
+	m, mOpp := matches(st.Settings, rec, pt, ptOpp)
+	newRating, newRatingOpp := m.UpdateRating(), mOpp.UpdateRating()
+	return [2]Change{
+		{ID: rec.ID, Time: rec.Time, Player: rec.Player, PlayerOpp: rec.PlayerOpp, Before: pt.RatingRaw, After: newRating, Expected: m.Expected, KFactor: m.KFactor, Bounds: m.Bounds},
+		{ID: rec.ID, Time: rec.Time, Player: rec.PlayerOpp, PlayerOpp: rec.Player, Before: ptOpp.RatingRaw, After: newRatingOpp, Expected: mOpp.Expected, KFactor: mOpp.KFactor, Bounds: mOpp.Bounds},
+	}, pt.after(newRating), ptOpp.after(newRatingOpp)
+}
+
+// store stores both players of a record after its events have been sent.
+func (st *Stream) store(rec Record, pt PlayerTeam, ptOpp PlayerTeam) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.players == nil {
+		st.players = map[string]PlayerTeam{}
+	}
+	st.players[rec.Player], st.players[rec.PlayerOpp] = pt, ptOpp
+}
+
+// player returns the current state of a player, seeding players the stream has not seen.
+func (st *Stream) player(player string) PlayerTeam {
+	if pt, ok := st.players[player]; ok {
+		return pt
+	}
+	return st.Settings.newPlayer(player)
+}
+
+// Rating returns the current rating of a player, and is safe to call while the stream is running.
+func (st *Stream) Rating(player string) float64 {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.player(player).RatingRaw
+}
+
+// Ratings returns a copy of the current rating of every player the stream has seen, and is safe to call while the
+// stream is running.
+func (st *Stream) Ratings() map[string]float64 {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	ratings := make(map[string]float64, len(st.players))
+	for player, pt := range st.players {
+		ratings[player] = pt.RatingRaw
+	}
+	return ratings
+}
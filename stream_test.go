@@ -0,0 +1,146 @@
+package elo_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestStreamRun(t *testing.T) {
+	settings := elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	records := []elo.Record{
+		{ID: "1", Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{ID: "2", Time: day(2), Player: "b", PlayerOpp: "c", Score: 1, ScoreOpp: 1},
+		{ID: "bad", Time: day(3), Player: "c", PlayerOpp: "c", Score: 1, ScoreOpp: 0},
+		{ID: "3", Time: day(4), Player: "c", PlayerOpp: "a", Score: 2, ScoreOpp: 0},
+	}
+	stream := elo.NewStream(settings)
+	var invalid []string
+	stream.OnInvalid = func(rec elo.Record, err error) {
+		invalid = append(invalid, rec.ID)
+	}
+
+	in := make(chan elo.Record)
+	out := make(chan elo.Change)
+	done := make(chan error)
+	go func() {
+		done <- stream.Run(context.Background(), in, out)
+	}()
+	go func() {
+		for _, rec := range records {
+			in <- rec
+		}
+		close(in)
+	}()
+	var changes []elo.Change
+	for change := range out {
+		changes = append(changes, change)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: The stream gives the same ratings as a ledger
+	ledger := elo.NewLedger(settings)
+	for _, rec := range records {
+		ledger.Insert(rec)
+	}
+	for player, expectedResult := range ledger.Ratings() {
+		if result := stream.Rating(player); math.Abs(result-expectedResult) > 1e-9 {
+			t.Errorf(ERROR_MESSAGE, expectedResult, result)
+		}
+	}
+
+	// Test case 2: Each player's events follow on from one another
+	last := map[string]float64{}
+	for _, change := range changes {
+		if before, ok := last[change.Player]; ok && before != change.Before {
+			t.Errorf(ERROR_MESSAGE, before, change.Before)
+		}
+		last[change.Player] = change.After
+	}
+	if len(changes) != 6 {
+		t.Errorf("Expected 6 changes, but got %d", len(changes))
+	}
+
+	// Test case 3: Invalid records are skipped and reported
+	if len(invalid) != 1 || invalid[0] != "bad" {
+		t.Errorf("Expected [bad], but got %v", invalid)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	run := func(stream *elo.Stream, ctx context.Context, in <-chan elo.Record, out chan<- elo.Change) error {
+		done := make(chan error)
+		go func() {
+			done <- stream.Run(ctx, in, out)
+		}()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("Expected Run to return after cancelling, but it was still blocked")
+			return nil
+		}
+	}
+
+	// Test case 1: Cancelling while no one reads out stops the stream without storing the record
+	stream := elo.NewStream(elo.New())
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan elo.Record, 1)
+	out := make(chan elo.Change)
+	in <- elo.Record{Player: "a", PlayerOpp: "b", Score: 1}
+	cancel()
+	if err := run(stream, ctx, in, out); err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+	if ratings := stream.Ratings(); len(ratings) != 0 {
+		t.Errorf("Expected no ratings, but got %v", ratings)
+	}
+
+	// Test case 2: Cancelling between the two events of a record leaves it unrated
+	stream = elo.NewStream(elo.New())
+	ctx, cancel = context.WithCancel(context.Background())
+	in = make(chan elo.Record, 1)
+	out = make(chan elo.Change)
+	in <- elo.Record{Player: "a", PlayerOpp: "b", Score: 1}
+	go func() {
+		<-out
+		cancel()
+	}()
+	if err := run(stream, ctx, in, out); err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+	if ratings := stream.Ratings(); len(ratings) != 0 {
+		t.Errorf("Expected no ratings, but got %v", ratings)
+	}
+
+	// Test case 3: Cancelling between records keeps the events of the records sent in step with the stored ratings
+	stream = elo.NewStream(elo.New())
+	ctx, cancel = context.WithCancel(context.Background())
+	in = make(chan elo.Record, 1)
+	out = make(chan elo.Change, 2)
+	in <- elo.Record{Player: "a", PlayerOpp: "b", Score: 1}
+	go func() {
+		for len(out) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+	if err := run(stream, ctx, in, out); err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+	changes := 0
+	for change := range out {
+		changes++
+		if result := stream.Rating(change.Player); result != change.After {
+			t.Errorf(ERROR_MESSAGE, change.After, result)
+		}
+	}
+	if changes != 2 {
+		t.Errorf("Expected 2 changes, but got %d", changes)
+	}
+}
@@ -0,0 +1,314 @@
+package elo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultByePoints float64 = 1      // DefaultByePoints is the number of points awarded to a player receiving a bye.
+	MaxPairingSteps  int     = 100000 // MaxPairingSteps caps the number of pairings tried before giving up on a round.
+)
+
+var (
+	ErrRoundIncomplete = errors.New("elo: the current round has boards without a result")
+	ErrNoRound         = errors.New("elo: there is no round in progress")
+	ErrNoPairing       = errors.New("elo: no pairing avoids a repeat opponent")
+	ErrPairingSteps    = errors.New("elo: pairing search gave up after MaxPairingSteps")
+	ErrUnknownBoard    = errors.New("elo: board is not part of the current round")
+)
+
+// Entrant is a player taking part in a Swiss tournament.
+type Entrant struct {
+	ID     string  // ID is the name of the player.
+	Rating float64 // Rating is the current rating of the player.
+	Points float64 // Points is the tournament score of the player.
+}
+
+// Board is a single game in a round, the first named player has the white pieces.
+type Board struct {
+	White    string
+	Black    string
+	Score    float64 // Score is the score of the white player.
+	ScoreOpp float64 // ScoreOpp is the score of the black player.
+	Played   bool    // Played is set once a result has been recorded.
+}
+
+// Round is the pairing of a single round of a Swiss tournament.
+type Round struct {
+	Boards []Board
+	Bye    string // Bye is the name of the player sitting out the round, if any.
+}
+
+// Swiss runs a Swiss system tournament, pairing players within score groups and rating each completed round.
+type Swiss struct {
+	Settings  Settings
+	ByePoints float64
+	entrants  map[string]*Entrant
+	players   map[string]PlayerTeam // players holds the rating, peak and games of each player for the policies and the provisional period.
+	seeds     []string
+	opponents map[string]map[string]bool
+	colors    map[string]int // colors holds the number of whites less the number of blacks for each player.
+	lastWhite map[string]bool
+	byes      map[string]int
+	rounds    []Round
+	current   *Round
+}
+
+// NewSwiss creates a tournament for the given entrants, seeding them by rating. Entrants known to the seeding strategy
+// of the settings are treated as seeded newcomers while provisional.
+func NewSwiss(s Settings, entrants []Entrant) *Swiss {
+	sw := &Swiss{
+		Settings:  s,
+		ByePoints: DefaultByePoints,
+		entrants:  map[string]*Entrant{},
+		players:   map[string]PlayerTeam{},
+		opponents: map[string]map[string]bool{},
+		colors:    map[string]int{},
+		lastWhite: map[string]bool{},
+		byes:      map[string]int{},
+	}
+	for _, e := range entrants {
+		e := e
+		sw.entrants[e.ID] = &e
+		pt := s.newPlayer(e.ID)
+		pt.RatingRaw = e.Rating
+		sw.players[e.ID] = pt
+		sw.opponents[e.ID] = map[string]bool{}
+		sw.seeds = append(sw.seeds, e.ID)
+	}
+	sort.SliceStable(sw.seeds, func(i, j int) bool {
+		return sw.entrants[sw.seeds[i]].Rating > sw.entrants[sw.seeds[j]].Rating
+	})
+	return sw
+}
+
+// order returns the player names ranked by points, then rating, then seed.
+func (sw *Swiss) order() []string {
+	order := append([]string{}, sw.seeds...)
+	sort.SliceStable(order, func(i, j int) bool {
+		e, eOpp := sw.entrants[order[i]], sw.entrants[order[j]]
+		if e.Points != eOpp.Points {
+			return e.Points > eOpp.Points
+		}
+		return e.Rating > eOpp.Rating
+	})
+	return order
+}
+
+// pairing is the state of the search for the pairing of a round.
+type pairing struct {
+	failed map[string]bool // failed holds the sets of remaining players known to have no pairing.
+	steps  int
+}
+
+// Pair pairs the next round. Players are ranked by points and rating, and the top half of each score group is
+// paired against the bottom half. Players left over float down to the next score group, repeat opponents are never
+// paired and, with an odd number of players, the lowest ranked player with the fewest byes whose absence still leaves
+// a valid pairing sits out.
+// It returns the new round, or an error if the previous round is incomplete or no pairing is possible.
+func (sw *Swiss) Pair() (Round, error) {
+	if sw.current != nil {
+		return Round{}, ErrRoundIncomplete
+	}
+	order := sw.order()
+	round := Round{}
+	p := &pairing{failed: map[string]bool{}}
+
+	var pairs [][2]string
+	ok := false
+	if len(order)%2 == 0 {
+		pairs, ok = sw.pairGroups(order, p)
+	} else {
+		// try the bye from the lowest ranked player up, fewest byes first
+		candidates := make([]int, len(order))
+		for i := range candidates {
+			candidates[i] = len(order) - 1 - i
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return sw.byes[order[candidates[i]]] < sw.byes[order[candidates[j]]]
+		})
+		for _, bye := range candidates {
+			rest := append(append(make([]string, 0, len(order)-1), order[:bye]...), order[bye+1:]...)
+			if pairs, ok = sw.pairGroups(rest, p); ok {
+				round.Bye = order[bye]
+				break
+			}
+			if p.steps > MaxPairingSteps {
+				break
+			}
+		}
+	}
+	if !ok {
+		if p.steps > MaxPairingSteps {
+			return Round{}, ErrPairingSteps
+		}
+		return Round{}, ErrNoPairing
+	}
+	for _, pair := range pairs {
+		round.Boards = append(round.Boards, sw.board(pair[0], pair[1]))
+	}
+	sw.current = &round
+	return round, nil
+}
+
+// pairGroups recursively pairs the first remaining player with the best available opponent, backtracking when the
+// remaining players cannot be paired without a repeat. Sets of players that cannot be paired are remembered, and the
+// search stops once it has tried MaxPairingSteps pairings.
+// It takes the following parameters:
+// - order ([]string): The remaining players in ranked order.
+// - p (*pairing): The state of the search.
+// It returns the pairs, and false if no complete pairing was found.
+func (sw *Swiss) pairGroups(order []string, p *pairing) ([][2]string, bool) {
+	if len(order) == 0 {
+		return nil, true
+	}
+	key := strings.Join(order, "\x00")
+	if p.failed[key] || p.steps > MaxPairingSteps {
+		return nil, false
+	}
+	player := order[0]
+	points := sw.entrants[player].Points
+	group := 1
+	for group < len(order) && sw.entrants[order[group]].Points == points {
+		group++
+	}
+
+	// prefer the opponent half way down the score group, then the rest of the group by distance, then lower groups
+	candidates := make([]int, 0, len(order)-1)
+	for i := 1; i < len(order); i++ {
+		candidates = append(candidates, i)
+	}
+	ideal := group / 2
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if (ci < group) != (cj < group) {
+			return ci < group
+		}
+		if ci < group {
+			return abs(ci-ideal) < abs(cj-ideal)
+		}
+		return false
+	})
+
+	for _, c := range candidates {
+		opp := order[c]
+		if sw.opponents[player][opp] {
+			continue
+		}
+		p.steps++
+		rest := make([]string, 0, len(order)-2)
+		rest = append(rest, order[1:c]...)
+		rest = append(rest, order[c+1:]...)
+		if pairs, ok := sw.pairGroups(rest, p); ok {
+			return append([][2]string{{player, opp}}, pairs...), true
+		}
+		if p.steps > MaxPairingSteps {
+			return nil, false
+		}
+	}
+	p.failed[key] = true
+	return nil, false
+}
+
+// board allocates colours to a pair, giving white to the player who has had fewer whites or, failing that, the player
+// who had black last time, alternating by round for the higher ranked player otherwise.
+func (sw *Swiss) board(player string, playerOpp string) Board {
+	white := len(sw.rounds)%2 == 0
+	if sw.colors[player] != sw.colors[playerOpp] {
+		white = sw.colors[player] < sw.colors[playerOpp]
+	} else if sw.lastWhite[player] != sw.lastWhite[playerOpp] {
+		white = !sw.lastWhite[player]
+	}
+	if white {
+		return Board{White: player, Black: playerOpp}
+	}
+	return Board{White: playerOpp, Black: player}
+}
+
+// Result records the result of a board in the current round.
+// It takes the following parameters:
+// - board (int): The index of the board in the round.
+// - score (float64): The score of the white player.
+// - scoreOpp (float64): The score of the black player.
+// It returns an error if there is no round in progress or the board does not exist.
+func (sw *Swiss) Result(board int, score float64, scoreOpp float64) error {
+	if sw.current == nil {
+		return ErrNoRound
+	}
+	if board < 0 || board >= len(sw.current.Boards) {
+		return ErrUnknownBoard
+	}
+	b := &sw.current.Boards[board]
+	b.Score, b.ScoreOpp, b.Played = score, scoreOpp, true
+	return nil
+}
+
+// Complete finishes the current round, awarding points and updating the ratings of both players on every board through
+// Match using the tournament settings.
+// It returns the records of the round, ready to be added to a Ledger, or an error if the round is incomplete.
+func (sw *Swiss) Complete() ([]Record, error) {
+	if sw.current == nil {
+		return nil, ErrNoRound
+	}
+	for _, b := range sw.current.Boards {
+		if !b.Played {
+			return nil, ErrRoundIncomplete
+		}
+	}
+
+	records := make([]Record, 0, len(sw.current.Boards))
+	for i, b := range sw.current.Boards {
+		rec := Record{
+			ID:        fmt.Sprintf("R%d.%d", len(sw.rounds)+1, i+1),
+			Player:    b.White,
+			PlayerOpp: b.Black,
+			Score:     b.Score,
+			ScoreOpp:  b.ScoreOpp,
+		}
+		white, black := sw.entrants[b.White], sw.entrants[b.Black]
+		pt, ptOpp := sw.players[b.White], sw.players[b.Black]
+		white.Rating, black.Rating = play(sw.Settings, rec, pt, ptOpp)
+		sw.players[b.White], sw.players[b.Black] = pt.after(white.Rating), ptOpp.after(black.Rating)
+		white.Points += b.Score
+		black.Points += b.ScoreOpp
+		sw.opponents[b.White][b.Black] = true
+		sw.opponents[b.Black][b.White] = true
+		sw.colors[b.White]++
+		sw.colors[b.Black]--
+		sw.lastWhite[b.White], sw.lastWhite[b.Black] = true, false
+		records = append(records, rec)
+	}
+	if sw.current.Bye != "" {
+		sw.entrants[sw.current.Bye].Points += sw.ByePoints
+		sw.byes[sw.current.Bye]++
+	}
+	sw.rounds = append(sw.rounds, *sw.current)
+	sw.current = nil
+	return records, nil
+}
+
+// Rounds returns the completed rounds of the tournament.
+func (sw *Swiss) Rounds() []Round {
+	return append([]Round{}, sw.rounds...)
+}
+
+// Standings returns the entrants ranked by points, then rating.
+func (sw *Swiss) Standings() []Entrant {
+	order := sw.order()
+	standings := make([]Entrant, len(order))
+	for i, id := range order {
+		standings[i] = *sw.entrants[id]
+	}
+	return standings
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
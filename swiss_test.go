@@ -0,0 +1,187 @@
+package elo_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestSwissPair(t *testing.T) {
+	entrants := []elo.Entrant{
+		{ID: "f", Rating: 1500},
+		{ID: "a", Rating: 2000},
+		{ID: "e", Rating: 1600},
+		{ID: "b", Rating: 1900},
+		{ID: "d", Rating: 1700},
+		{ID: "c", Rating: 1800},
+	}
+	sw := elo.NewSwiss(elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0)), entrants)
+
+	// Test case 1: First round pairs the top half against the bottom half by rating
+	round, err := sw.Pair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPairs := [][2]string{{"a", "d"}, {"b", "e"}, {"c", "f"}}
+	for i, b := range round.Boards {
+		if !(b.White == expectedPairs[i][0] && b.Black == expectedPairs[i][1]) &&
+			!(b.White == expectedPairs[i][1] && b.Black == expectedPairs[i][0]) {
+			t.Errorf("Expected %v, but got %s against %s", expectedPairs[i], b.White, b.Black)
+		}
+	}
+
+	// Test case 2: The round cannot be completed or re-paired until every result is in
+	if _, err := sw.Complete(); err != elo.ErrRoundIncomplete {
+		t.Errorf("Expected %v, but got %v", elo.ErrRoundIncomplete, err)
+	}
+	if _, err := sw.Pair(); err != elo.ErrRoundIncomplete {
+		t.Errorf("Expected %v, but got %v", elo.ErrRoundIncomplete, err)
+	}
+
+	// Test case 3: Over three rounds nobody meets the same opponent twice and colours stay balanced
+	met := map[[2]string]bool{}
+	whites := map[string]int{}
+	for r := 0; r < 3; r++ {
+		if r > 0 {
+			if round, err = sw.Pair(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i, b := range round.Boards {
+			if met[[2]string{b.White, b.Black}] {
+				t.Errorf("Expected no repeat, but %s met %s again", b.White, b.Black)
+			}
+			met[[2]string{b.White, b.Black}], met[[2]string{b.Black, b.White}] = true, true
+			whites[b.White]++
+			if err := sw.Result(i, 1, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		records, err := sw.Complete()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 3 {
+			t.Errorf("Expected 3 records, but got %d", len(records))
+		}
+	}
+	for id, count := range whites {
+		if count < 1 || count > 2 {
+			t.Errorf("Expected %s to have one or two whites, but got %d", id, count)
+		}
+	}
+
+	// Test case 4: Completed rounds update ratings through Match, conserving the rating pool
+	total := 0.0
+	for _, e := range sw.Standings() {
+		total += e.Rating
+	}
+	expectedResult := 10500.0
+	if math.Abs(total-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, total)
+	}
+}
+
+func TestSwissBye(t *testing.T) {
+	entrants := []elo.Entrant{
+		{ID: "a", Rating: 2000},
+		{ID: "b", Rating: 1900},
+		{ID: "c", Rating: 1800},
+	}
+	sw := elo.NewSwiss(elo.New(), entrants)
+
+	// Test case 1: The lowest ranked player receives the bye
+	round, err := sw.Pair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Bye != "c" {
+		t.Errorf("Expected c to have the bye, but got %s", round.Bye)
+	}
+	if err := sw.Result(0, 0.5, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sw.Complete(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 2: The bye moves on to a player who has not had one
+	round, err = sw.Pair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Bye == "c" {
+		t.Errorf("Expected the bye to move on, but c had it again")
+	}
+	standings := sw.Standings()
+	if standings[0].ID != "c" || standings[0].Points != elo.DefaultByePoints {
+		t.Errorf("Expected c to lead on %f points, but got %s on %f", elo.DefaultByePoints, standings[0].ID, standings[0].Points)
+	}
+}
+
+// pairable reports whether players can be paired, with one sitting out if there is an odd number, without repeats.
+func pairable(players []string, met map[[2]string]bool, bye bool) bool {
+	if len(players) == 0 {
+		return true
+	}
+	if bye {
+		for i := range players {
+			rest := append(append([]string{}, players[:i]...), players[i+1:]...)
+			if pairable(rest, met, false) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 1; i < len(players); i++ {
+		if met[[2]string{players[0], players[i]}] {
+			continue
+		}
+		rest := append(append([]string{}, players[1:i]...), players[i+1:]...)
+		if pairable(rest, met, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSwissPairExhaustive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{5, 7} {
+		for trial := 0; trial < 20; trial++ {
+			entrants := make([]elo.Entrant, n)
+			players := make([]string, n)
+			for i := range entrants {
+				players[i] = fmt.Sprint(i)
+				entrants[i] = elo.Entrant{ID: players[i], Rating: float64(2000 - 10*i)}
+			}
+			sw := elo.NewSwiss(elo.New(), entrants)
+			met := map[[2]string]bool{}
+
+			// Test case 1: A round is only refused when no bye leaves a valid pairing
+			for r := 0; r < n; r++ {
+				round, err := sw.Pair()
+				if err == elo.ErrNoPairing {
+					if pairable(players, met, true) {
+						t.Errorf("Expected a pairing for %d players in round %d, but got %v", n, r+1, err)
+					}
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i, b := range round.Boards {
+					met[[2]string{b.White, b.Black}], met[[2]string{b.Black, b.White}] = true, true
+					score := float64(rng.Intn(3)) / 2
+					sw.Result(i, score, 1-score)
+				}
+				if _, err := sw.Complete(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package elo
 
+import "math"
+
+// Floor is a function type that defines the signature of a floor function, giving the lowest rating a player may fall to from their peak rating.
+type Floor func(peak float64) float64
+
 // Update is a function type that defines the signature of an update function for the rating system.
 type Update func(observed float64, expected float64, kFactor float64) float64
 
@@ -62,25 +67,62 @@ func (s *Settings) ApplyMaxChangeAbs(oldRating float64, newRating float64) float
 	return ApplyMaxChange(minRating, maxRating, newRating)
 }
 
-// update calculates a new rating based on the observed and expected values using the specified update function and applies maximum changes if configured.
+// change calculates the change in rating based on the observed and expected values using the specified update function.
 // It takes the following parameters:
-// - rating (float64): The current rating value.
 // - observed (float64): The actual observed value.
 // - expected (float64): The expected value.
-// It returns the adjusted new rating as a float64 value.
-func (s *Settings) update(rating float64, observed float64, expected float64) float64 {
+// - kFactor (float64): The effective K-factor of the match.
+// It returns the change in rating as a float64 value.
+func (s *Settings) change(observed float64, expected float64, kFactor float64) float64 {
 	var updateFunc Update
 	if s.UpdateFunc != nil {
 		updateFunc = *s.UpdateFunc
 	} else {
 		updateFunc = UpdateExpected
 	}
-	change := updateFunc(observed, expected, s.kFactor)
-	newRating := rating + change
-	if s.maxChangePerc != 0 {
-		newRating = s.ApplyMaxChangePerc(rating, newRating)
-	} else if s.maxChangeAbs != 0 {
-		newRating = s.ApplyMaxChangeAbs(rating, newRating)
+	return updateFunc(observed, expected, kFactor)
+}
+
+// update calculates a new rating based on the observed and expected values using the specified update function and runs the policy pipeline over it.
+// It takes the following parameters:
+// - pt (PlayerTeam): The player or team before the update, whose Rating the change is applied to.
+// - observed (float64): The actual observed value.
+// - expected (float64): The expected value.
+// - kFactor (float64): The effective K-factor of the match.
+// - nudge (float64): A further change, such as a move toward the market, applied before the policies.
+// It returns the adjusted new rating as a float64 value and the adjustments of the policies that bound it.
+func (s *Settings) update(pt PlayerTeam, observed float64, expected float64, kFactor float64, nudge float64) (float64, []Clamp) {
+	return s.applyPolicies(pt, pt.Rating+s.change(observed, expected, kFactor)+nudge)
+}
+
+// marketNudge calculates the move of a rating toward the rating difference implied by the market. The gap is taken once
+// from the home side's view, so the home side moves by half of the weighted gap and the away side by the same amount
+// the other way, leaving the total of the two ratings unchanged.
+// It takes the following parameters:
+// - pt (PlayerTeam): The subject player or team.
+// - ptOpp (PlayerTeam): The opposing player or team.
+// - market (*float64): The de-margined expected score of the subject implied by the market, nil if there is none.
+// - away (bool): Whether the subject is the away side.
+// It returns the change in the subject's rating as a float64 value, 0 if the expected function has no inverse.
+func (s *Settings) marketNudge(pt PlayerTeam, ptOpp PlayerTeam, market *float64, away bool) float64 {
+	if market == nil || s.marketWeight == 0 {
+		return 0
+	}
+	home, homeOpp, marketHome, sign := pt, ptOpp, *market, 1.0
+	if away {
+		home, homeOpp, marketHome, sign = ptOpp, pt, 1-*market, -1
 	}
-	return newRating
+	gap := s.Inverse(marketHome) - (home.Rating - homeOpp.Rating)
+	if math.IsNaN(gap) {
+		return 0
+	}
+	return sign * s.marketWeight * gap / 2
+}
+
+// FloorFromPeak is a floor function that places the floor 200 points below the peak rating, rounded down to the hundred, as in the USCF rating floors.
+// It takes the following parameters:
+// - peak (float64): The highest rating the player has reached.
+// It returns the floor as a float64 value.
+func FloorFromPeak(peak float64) float64 {
+	return math.Floor((peak-200)/100) * 100
 }
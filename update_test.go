@@ -148,3 +148,68 @@ func TestSettingsApplymaxChangeAbs(t *testing.T) {
 		t.Errorf(ERROR_MESSAGE, expectedResult, result)
 	}
 }
+
+func TestFloorFromPeak(t *testing.T) {
+	// Test case 1: Peak on the hundred
+	result := elo.FloorFromPeak(2000)
+	expectedResult := 1800.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 2: Peak rounded down to the hundred
+	result = elo.FloorFromPeak(2099)
+	expectedResult = 1800.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+}
+
+func TestMatchUpdateRatingBounds(t *testing.T) {
+	// Test case 1: A loss is stopped at the floor from the peak rating
+	m := elo.Match{
+		Pt:       elo.PlayerTeam{RatingRaw: 1810, Peak: 2050},
+		PtOpp:    elo.PlayerTeam{RatingRaw: 1810},
+		Score:    0,
+		ScoreOpp: 1,
+		Settings: elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithFloorFunc(elo.FloorFromPeak)),
+	}
+	result := m.UpdateRating()
+	expectedResult := 1800.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	if len(m.Bounds) != 1 || m.Bounds[0] != elo.BoundFloor {
+		t.Errorf("Expected %v, but got %v", []string{elo.BoundFloor}, m.Bounds)
+	}
+
+	// Test case 2: A rating already below the floor is not lifted to it
+	m.Pt = elo.PlayerTeam{RatingRaw: 1700, Peak: 2050}
+	result = m.UpdateRating()
+	expectedResult = 1700.0
+	if result != expectedResult {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 3: Absolute minimum and maximum ratings
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0), elo.WithMinRating(0), elo.WithMaxRating(20))
+	m.Pt, m.PtOpp = elo.PlayerTeam{RatingRaw: 10}, elo.PlayerTeam{RatingRaw: 10}
+	result = m.UpdateRating()
+	expectedResult = 0
+	if result != expectedResult || len(m.Bounds) != 1 || m.Bounds[0] != elo.BoundMinRating {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+	m.Score, m.ScoreOpp = 1, 0
+	result = m.UpdateRating()
+	expectedResult = 20
+	if result != expectedResult || len(m.Bounds) != 1 || m.Bounds[0] != elo.BoundMaxRating {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result)
+	}
+
+	// Test case 4: No bound is reported for an unbounded update
+	m.Settings = elo.New(elo.WithDecayFactor(1), elo.WithHomeAdvantage(0))
+	m.UpdateRating()
+	if m.Bounds != nil {
+		t.Errorf("Expected no bounds, but got %v", m.Bounds)
+	}
+}
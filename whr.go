@@ -0,0 +1,224 @@
+package elo
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultWHRVariance float64       = 14
+	DefaultWHRPeriod   time.Duration = 24 * time.Hour
+)
+
+// TrajectoryPoint is the estimated rating of a player at a point in time.
+type TrajectoryPoint struct {
+	Time      time.Time
+	Rating    float64
+	Deviation float64 // Deviation is the standard deviation of the rating.
+}
+
+// WHR fits Rémi Coulom's Whole-History Rating, in which each player's rating follows a Wiener process and every game,
+// earlier or later, informs the estimate at every point in time. Ratings are fitted by Newton's method one player at a
+// time and returned on the scale of ExpProbability with the configured c, centred on the initial rating.
+type WHR struct {
+	Settings  Settings
+	Variance  float64       // Variance is the variance of the rating change per day of the Wiener process, in rating points squared.
+	Period    time.Duration // Period is the length of time over which a player's rating is treated as constant.
+	MaxIter   int           // MaxIter is the maximum number of passes over the players.
+	Tolerance float64       // Tolerance is the largest change in rating at which the fit is considered converged.
+}
+
+// NewWHR creates a Whole-History Rating fit with the default variance and a period of a day.
+func NewWHR(s Settings) WHR {
+	return WHR{
+		Settings:  s,
+		Variance:  DefaultWHRVariance,
+		Period:    DefaultWHRPeriod,
+		MaxIter:   DefaultMaxIter,
+		Tolerance: DefaultTolerance,
+	}
+}
+
+// whrGame is a game played by a player on a given day, against an opponent on their own day.
+type whrGame struct {
+	opp    *whrDay
+	scored float64
+}
+
+// whrDay is a period in which a player has played, with their natural rating for it.
+type whrDay struct {
+	time  time.Time
+	r     float64
+	games []whrGame
+}
+
+// whrPlayer holds the days on which a player has played in time order.
+type whrPlayer struct {
+	days []*whrDay
+}
+
+// players builds the time ordered days of every player from the records, returning them with their sorted names.
+func (w WHR) players(records []Record) (map[string]*whrPlayer, []string) {
+	period := w.Period
+	if period <= 0 {
+		period = DefaultWHRPeriod
+	}
+	days := map[string]map[time.Time]*whrDay{}
+	get := func(player string, t time.Time) *whrDay {
+		if days[player] == nil {
+			days[player] = map[time.Time]*whrDay{}
+		}
+		if days[player][t] == nil {
+			days[player][t] = &whrDay{time: t}
+		}
+		return days[player][t]
+	}
+	for _, rec := range records {
+		if rec.Player == rec.PlayerOpp {
+			continue
+		}
+		t := rec.Time.Truncate(period)
+		observed := math.Max(0, math.Min(1, w.Settings.observed(rec.Score, rec.ScoreOpp)))
+		d, dOpp := get(rec.Player, t), get(rec.PlayerOpp, t)
+		d.games = append(d.games, whrGame{opp: dOpp, scored: observed})
+		dOpp.games = append(dOpp.games, whrGame{opp: d, scored: 1 - observed})
+	}
+
+	players := map[string]*whrPlayer{}
+	names := make([]string, 0, len(days))
+	for name, byTime := range days {
+		p := &whrPlayer{}
+		for _, d := range byTime {
+			p.days = append(p.days, d)
+		}
+		sort.Slice(p.days, func(i, j int) bool { return p.days[i].time.Before(p.days[j].time) })
+		players[name] = p
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return players, names
+}
+
+// system builds the gradient of the log likelihood for a player, and the diagonal and off diagonal of the negated
+// Hessian, which is tridiagonal because the Wiener prior only links neighbouring days. A virtual win and loss against
+// a player of the initial rating on the first day keeps the fit well defined.
+// It takes the following parameters:
+// - w2 (float64): The variance of the rating change per day on the natural scale.
+// It returns the gradient, the diagonal and the off diagonal.
+func (p *whrPlayer) system(w2 float64) ([]float64, []float64, []float64) {
+	n := len(p.days)
+	gradient := make([]float64, n)
+	diag := make([]float64, n)
+	off := make([]float64, n)
+	for i, d := range p.days {
+		for _, g := range d.games {
+			prob := sigmoid(d.r - g.opp.r)
+			gradient[i] += g.scored - prob
+			diag[i] += prob * (1 - prob)
+		}
+		if i == 0 {
+			prob := sigmoid(d.r)
+			gradient[i] += 1 - 2*prob
+			diag[i] += 2 * prob * (1 - prob)
+		}
+		if i+1 < n {
+			days := p.days[i+1].time.Sub(d.time).Hours() / 24
+			sigma2 := math.Max(w2*days, 1e-12)
+			change := (p.days[i+1].r - d.r) / sigma2
+			gradient[i] += change
+			gradient[i+1] -= change
+			diag[i] += 1 / sigma2
+			diag[i+1] += 1 / sigma2
+			off[i] = -1 / sigma2
+		}
+	}
+	return gradient, diag, off
+}
+
+// solveTridiagonal solves a symmetric tridiagonal system by the Thomas algorithm.
+// It takes the following parameters:
+// - diag ([]float64): The diagonal of the matrix.
+// - off ([]float64): The off diagonal of the matrix, off[i] linking rows i and i+1.
+// - rhs ([]float64): The right hand side.
+// It returns the solution.
+func solveTridiagonal(diag []float64, off []float64, rhs []float64) []float64 {
+	n := len(diag)
+	c := make([]float64, n)
+	x := make([]float64, n)
+	denom := diag[0]
+	x[0] = rhs[0] / denom
+	for i := 1; i < n; i++ {
+		c[i-1] = off[i-1] / denom
+		denom = diag[i] - off[i-1]*c[i-1]
+		x[i] = (rhs[i] - off[i-1]*x[i-1]) / denom
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= c[i] * x[i+1]
+	}
+	return x
+}
+
+// inverseDiagonal returns the diagonal of the inverse of a symmetric positive definite tridiagonal matrix, combining
+// the pivots of a forward and a backward elimination.
+func inverseDiagonal(diag []float64, off []float64) []float64 {
+	n := len(diag)
+	forward := make([]float64, n)
+	backward := make([]float64, n)
+	forward[0] = diag[0]
+	for i := 1; i < n; i++ {
+		forward[i] = diag[i] - off[i-1]*off[i-1]/forward[i-1]
+	}
+	backward[n-1] = diag[n-1]
+	for i := n - 2; i >= 0; i-- {
+		backward[i] = diag[i] - off[i]*off[i]/backward[i+1]
+	}
+	inv := make([]float64, n)
+	for i := range inv {
+		inv[i] = 1 / (forward[i] + backward[i] - diag[i])
+	}
+	return inv
+}
+
+// Fit fits the rating trajectory of every player appearing in the records.
+// It takes the following parameters:
+// - records ([]Record): The results to fit, in any order.
+// It returns the rating and its deviation for each period each player played in, in time order.
+func (w WHR) Fit(records []Record) map[string][]TrajectoryPoint {
+	players, names := w.players(records)
+	scale := w.Settings.c / math.Ln10
+	w2 := w.Variance / (scale * scale)
+
+	for iter := 0; iter < w.MaxIter; iter++ {
+		largest := 0.0
+		for _, name := range names {
+			p := players[name]
+			gradient, diag, off := p.system(w2)
+			step := solveTridiagonal(diag, off, gradient)
+			for i, d := range p.days {
+				d.r += step[i]
+				largest = math.Max(largest, math.Abs(step[i]))
+			}
+		}
+		if largest < w.Tolerance {
+			break
+		}
+	}
+
+	trajectories := make(map[string][]TrajectoryPoint, len(names))
+	for _, name := range names {
+		p := players[name]
+		_, diag, off := p.system(w2)
+		variance := inverseDiagonal(diag, off)
+		points := make([]TrajectoryPoint, len(p.days))
+		for i, d := range p.days {
+			points[i] = TrajectoryPoint{
+				Time:      d.time,
+				Rating:    w.Settings.InitRating + d.r*scale,
+				Deviation: math.Sqrt(variance[i]) * scale,
+			}
+		}
+		trajectories[name] = points
+	}
+	return trajectories
+}
@@ -0,0 +1,49 @@
+package elo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/watson-sam/elo"
+)
+
+func TestWHRFit(t *testing.T) {
+	settings := elo.New(elo.WithInitRating(1500))
+	whr := elo.NewWHR(settings)
+
+	// Test case 1: Even results leave both players at the initial rating
+	result := whr.Fit([]elo.Record{
+		{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0},
+		{Time: day(1), Player: "b", PlayerOpp: "a", Score: 1, ScoreOpp: 0},
+	})
+	expectedResult := 1500.0
+	if math.Abs(result["a"][0].Rating-expectedResult) > 0.0001 {
+		t.Errorf(ERROR_MESSAGE, expectedResult, result["a"][0].Rating)
+	}
+
+	// Test case 2: Later wins revise the earlier estimate upwards
+	records := []elo.Record{
+		{Time: day(1), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 1},
+	}
+	for i := 0; i < 5; i++ {
+		records = append(records, elo.Record{Time: day(10), Player: "a", PlayerOpp: "b", Score: 1, ScoreOpp: 0})
+	}
+	result = whr.Fit(records)
+	if len(result["a"]) != 2 {
+		t.Fatalf("Expected 2 points, but got %d", len(result["a"]))
+	}
+	if result["a"][0].Rating <= 1500 {
+		t.Errorf("Expected the first rating to be above 1500, but got %f", result["a"][0].Rating)
+	}
+	if result["a"][1].Rating <= result["a"][0].Rating {
+		t.Errorf("Expected the rating to rise, but got %f then %f", result["a"][0].Rating, result["a"][1].Rating)
+	}
+	if math.Abs(result["a"][1].Rating-1500-(1500-result["b"][1].Rating)) > 0.0001 {
+		t.Errorf("Expected symmetric ratings, but got %f and %f", result["a"][1].Rating, result["b"][1].Rating)
+	}
+
+	// Test case 3: The day with more games has the smaller deviation
+	if result["a"][1].Deviation >= result["a"][0].Deviation {
+		t.Errorf("Expected a smaller deviation than %f, but got %f", result["a"][0].Deviation, result["a"][1].Deviation)
+	}
+}